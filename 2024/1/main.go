@@ -1,63 +1,56 @@
-package main
+package day1
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"path/filepath"
+	"aoc"
 	"sort"
-	"strconv"
-	"strings"
 )
 
-func readData(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
+type Lists struct {
+	left  []int
+	right []int
+}
 
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
+func init() {
+	aoc.Register(2024, 1, aoc.Solution[Lists]{
+		Parse: formatData,
+		Part1: func(lists Lists) (aoc.Answer, error) { return part1(lists.left, lists.right) },
+		Part2: func(lists Lists) (aoc.Answer, error) { return part2(lists.left, lists.right) },
+	})
 }
 
-func formatData(rows []string) ([]int, []int, error) {
+func formatData(in aoc.Input) (Lists, error) {
 	var left []int
 	var right []int
-	for _, row := range rows {
-		parts := strings.Split(row, "   ")
-		if len(parts) == 2 {
-			a, err := strconv.Atoi(parts[0])
-			if err != nil {
-				return nil, nil, err
-			}
-			b, err := strconv.Atoi(parts[1])
-			if err != nil {
-				return nil, nil, err
-			}
-			left = append(left, a)
-			right = append(right, b)
+	for _, row := range in.Rows() {
+		fields := row.Fields()
+		if len(fields) != 2 {
+			return Lists{}, row.Errorf("expected two location IDs, got %q", row)
 		}
+		a, err := fields[0].Int()
+		if err != nil {
+			return Lists{}, err
+		}
+		b, err := fields[1].Int()
+		if err != nil {
+			return Lists{}, err
+		}
+		left = append(left, a)
+		right = append(right, b)
 	}
-	return left, right, nil
+	return Lists{left, right}, nil
 }
 
-func part1(left, right []int) int {
+func part1(left, right []int) (aoc.Answer, error) {
 	sort.Ints(left)
 	sort.Ints(right)
 	distance := 0
 	for i := 0; i < len(left); i++ {
 		distance += abs(left[i] - right[i])
 	}
-	fmt.Printf("{ distance: %d }\n", distance)
-	return distance
+	return aoc.Int(distance), nil
 }
 
-func part2(left, right []int) int {
+func part2(left, right []int) (aoc.Answer, error) {
 	similarity := 0
 	mapRight := make(map[int]int)
 	for _, value := range right {
@@ -66,8 +59,7 @@ func part2(left, right []int) int {
 	for _, value := range left {
 		similarity += value * mapRight[value]
 	}
-	fmt.Printf("{ similarity: %d }\n", similarity)
-	return similarity
+	return aoc.Int(similarity), nil
 }
 
 func abs(x int) int {
@@ -76,21 +68,3 @@ func abs(x int) int {
 	}
 	return x
 }
-
-func main() {
-	filePath := filepath.Join(".", "data.txt")
-	rows, err := readData(filePath)
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	left, right, err := formatData(rows)
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	part1(left, right)
-	part2(left, right)
-}
@@ -1,174 +1,94 @@
-package main
+package day10
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
+	"aoc"
+	"aoc/grid"
+	"aoc/point"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 10, aoc.Solution[*grid.Grid[int]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) ([][]string, error) {
-	grid := make([][]string, len(rows))
+// impassable is the height of the "." cells some examples have.
+const impassable = -1
 
-	for i, row := range rows {
-		grid[i] = strings.Split(row, "")
+func formatData(in aoc.Input) (*grid.Grid[int], error) {
+	rows, err := in.Grid("0123456789.")
+	if err != nil {
+		return nil, err
 	}
-
-	return grid, nil
+	return grid.Parse(rows, func(c aoc.Line) (int, error) {
+		if c.Text == "." {
+			return impassable, nil
+		}
+		return c.Int()
+	})
 }
 
-func bfs(
-	grid [][]string,
-	startPoints [][]int,
-	aggregator func(x, y, val int, result interface{}),
-	visited map[string]bool,
-	result interface{},
-) {
-
-	// init queue
-	queue := make([][]int, 0)
-	queue = append(queue, startPoints...)
-
-	directions := [][]int{
-		{-1, 0}, {1, 0}, {0, -1}, {0, 1},
-	}
+// bfs walks every trail up from start, one height at a time, calling visit
+// for each cell of each trail. Cells on several trails are visited for each.
+func bfs(heights *grid.Grid[int], start int, visit func(cell, height int)) {
+	queue := []int{start}
 
 	for len(queue) > 0 {
 		cell := queue[0]
 		queue = queue[1:]
-		x, y := cell[0], cell[1]
-		cellValue, err := strconv.Atoi(grid[x][y])
-		if err != nil {
-			continue
-		}
-		cellKey := fmt.Sprintf("%d_%d", x, y)
-		visited[cellKey] = true
-
-		// process
-		aggregator(x, y, cellValue, result)
+		height := heights.At(cell)
 
-		for _, dir := range directions {
-			newX, newY := x+dir[0], y+dir[1]
+		visit(cell, height)
 
-			// out of bounds
-			if newX < 0 || newY < 0 || newX >= len(grid) || newY >= len(grid[0]) {
-				continue
-			}
-
-			neighbourValue, err := strconv.Atoi(grid[newX][newY])
-			if err != nil {
-				continue
-			}
-			key := fmt.Sprintf("%d_%d", newX, newY)
-
-			// queue up
-			if neighbourValue == cellValue+1 {
-				queue = append(queue, []int{newX, newY})
-				visited[key] = true
+		for neighbour := range heights.Neighbours(cell, point.Cardinal) {
+			if heights.At(neighbour) == height+1 {
+				queue = append(queue, neighbour)
 			}
 		}
 	}
 }
 
-func part1(grid [][]string) int {
-
-	startPoints := make([][]int, 0)
-	for i := range grid {
-		for j := range grid[i] {
-			if grid[i][j] == fmt.Sprint(0) {
-				startPoints = append(startPoints, []int{i, j})
-			}
-		}
-	}
-
-	trailHeadScores := make(map[string]int)
-	for _, startPoint := range startPoints {
-
-		cellVisited := make(map[string]bool)
-		summitVisited := make(map[string]bool)
-
-		scoresAggregator := func(x, y, value int, result interface{}) {
-
-			key := fmt.Sprintf("%d_%d", x, y)
-
-			if value == 9 && !summitVisited[key] {
-				trailHeadKey := fmt.Sprintf("%d_%d", startPoint[0], startPoint[1])
-				summitVisited[key] = true
-				result.(map[string]int)[trailHeadKey]++
-			}
-		}
-
-		bfs(grid, [][]int{startPoint}, scoresAggregator, cellVisited, trailHeadScores)
-	}
-
-	sum := 0
-	for _, score := range trailHeadScores {
-		sum += score
-	}
-	fmt.Println(sum)
-	return sum
+// trailHeads are the cells at height 0.
+func trailHeads(heights *grid.Grid[int]) []int {
+	return heights.FindAll(func(height int) bool { return height == 0 })
 }
 
-func part2(grid [][]string) int {
-
-	startPoints := make([][]int, 0)
-	for i := range grid {
-		for j := range grid[i] {
-			if grid[i][j] == fmt.Sprint(0) {
-				startPoints = append(startPoints, []int{i, j})
-			}
+// score counts the summits reachable from a trailhead.
+func score(heights *grid.Grid[int], trailHead int) int {
+	summitVisited := make(map[int]bool)
+	bfs(heights, trailHead, func(cell, height int) {
+		if height == 9 {
+			summitVisited[cell] = true
 		}
-	}
-
-	trailHeadScores := make(map[string]int)
-	for _, startPoint := range startPoints {
-
-		cellVisited := make(map[string]bool)
-		summitVisited := make(map[string]bool)
-
-		scoresAggregator := func(x, y, value int, result interface{}) {
-
-			key := fmt.Sprintf("%d_%d", x, y)
+	})
+	return len(summitVisited)
+}
 
-			if value == 9 {
-				trailHeadKey := fmt.Sprintf("%d_%d", startPoint[0], startPoint[1])
-				summitVisited[key] = true
-				result.(map[string]int)[trailHeadKey]++
-			}
+// rating counts the trails from a trailhead to any summit.
+func rating(heights *grid.Grid[int], trailHead int) int {
+	trails := 0
+	bfs(heights, trailHead, func(cell, height int) {
+		if height == 9 {
+			trails++
 		}
+	})
+	return trails
+}
 
-		bfs(grid, [][]int{startPoint}, scoresAggregator, cellVisited, trailHeadScores)
-	}
-
+func part1(heights *grid.Grid[int]) (aoc.Answer, error) {
 	sum := 0
-	for _, score := range trailHeadScores {
-		sum += score
+	for _, trailHead := range trailHeads(heights) {
+		sum += score(heights, trailHead)
 	}
-	fmt.Println(sum)
-	return sum
+	return aoc.Int(sum), nil
 }
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData, err := formatData(data)
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+func part2(heights *grid.Grid[int]) (aoc.Answer, error) {
+	sum := 0
+	for _, trailHead := range trailHeads(heights) {
+		sum += rating(heights, trailHead)
 	}
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
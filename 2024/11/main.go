@@ -1,32 +1,48 @@
-package main
+package day11
 
 import (
+	"aoc"
+	"aoc/memo"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 11, aoc.Solution[Stones]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) []int {
-	stonesStr := strings.Split(string(rows[0]), " ")
-	stones := make([]int, len(stonesStr))
+// Stones are the engraved stones along with how many times they are blinked at
+// in each part.
+type Stones struct {
+	stones         []int
+	depth1, depth2 int
+}
+
+func formatData(in aoc.Input) (Stones, error) {
+	depth1, err := in.Int("depth1", 25)
+	if err != nil {
+		return Stones{}, err
+	}
+	depth2, err := in.Int("depth2", 75)
+	if err != nil {
+		return Stones{}, err
+	}
 
-	for i := range stonesStr {
-		value, _ := strconv.Atoi(stonesStr[i])
-		stones[i] = value
+	rows := in.Rows()
+	if len(rows) != 1 {
+		return Stones{}, rows[0].Errorf("expected the stones on a single line")
+	}
+	stones, err := rows[0].Ints(" ")
+	if err != nil {
+		return Stones{}, err
 	}
 
-	return stones
+	return Stones{stones, depth1, depth2}, nil
 }
 
 func processStone(stone int) []int {
@@ -49,8 +65,8 @@ func processStone(stone int) []int {
 	}
 }
 
-func part1(stones []int) int {
-	depth := 25
+func part1(data Stones) (aoc.Answer, error) {
+	stones, depth := data.stones, data.depth1
 
 	for i := 0; i < depth; i++ {
 		// fmt.Println(depth)
@@ -62,44 +78,32 @@ func part1(stones []int) int {
 		stones = nextStones
 	}
 
-	fmt.Println(len(stones))
-	return len(stones)
+	return aoc.Int(len(stones)), nil
 }
 
-func calculateStoneSize(stone int, depth int, memo map[string]int, syncLock *sync.Mutex) int {
+// calculateStoneSize is how many stones a stone ends up as after being blinked
+// at depth more times. Sizes are keyed by the stone packed with the depth,
+// stones staying well within the 48 bits Pack2 leaves them.
+func calculateStoneSize(stone int, depth int, sizes *memo.Table[uint64, int]) int {
 	if depth == 0 {
 		return 1
 	}
 
-	key := fmt.Sprintf("%d_%d", stone, depth)
-
-	syncLock.Lock()
-	cached, exists := memo[key]
-	syncLock.Unlock()
-
-	if exists {
-		return cached
-	}
-
-	nextStones := processStone(stone)
-	localSum := 0
-	for _, nextStone := range nextStones {
-		localSum += calculateStoneSize(nextStone, depth-1, memo, syncLock)
-	}
-
-	syncLock.Lock()
-	memo[key] = localSum
-	syncLock.Unlock()
-	return localSum
+	return sizes.Do(memo.Pack2(stone, depth), func() int {
+		localSum := 0
+		for _, nextStone := range processStone(stone) {
+			localSum += calculateStoneSize(nextStone, depth-1, sizes)
+		}
+		return localSum
+	})
 }
 
-func part2(stones []int) int {
-	depth := 75
+func part2(data Stones) (aoc.Answer, error) {
+	stones, depth := data.stones, data.depth2
 	results := make(chan int, len(stones))
 
-	var syncLock sync.Mutex
 	var waitGroup sync.WaitGroup
-	memo := make(map[string]int)
+	sizes := memo.NewSync[uint64, int]()
 
 	for _, stone := range stones {
 
@@ -108,7 +112,7 @@ func part2(stones []int) int {
 		go func(current int) {
 			defer waitGroup.Done()
 
-			result := calculateStoneSize(current, depth, memo, &syncLock)
+			result := calculateStoneSize(current, depth, sizes)
 			results <- result
 			// fmt.Printf("stone %d - count %d \n", i, result)
 		}(stone)
@@ -121,18 +125,9 @@ func part2(stones []int) int {
 		sum += <-results
 	}
 
-	fmt.Println(sum)
-	return sum
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+	if aoc.Visual() {
+		fmt.Println("stone sizes:", sizes.Stats())
 	}
 
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
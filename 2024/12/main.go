@@ -1,166 +1,111 @@
-package main
+package day12
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"aoc"
+	"aoc/grid"
+	"aoc/point"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
+func init() {
+	aoc.Register(2024, 12, aoc.Solution[*grid.Grid[rune]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func formatData(in aoc.Input) (*grid.Grid[rune], error) {
+	rows, err := in.Grid("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
 	if err != nil {
 		return nil, err
 	}
-	return strings.Split(string(data), "\n"), nil
-}
-
-func formatData(rows []string) [][]string {
-	grid := make([][]string, len(rows))
-
-	for i, row := range rows {
-		grid[i] = strings.Split(row, "")
-	}
-
-	return grid
+	return grid.Runes(rows)
 }
 
 type Area struct {
-	region    string
+	region    rune
 	size      int
 	perimeter int
 	corners   int
 }
 
-func exploreRegion(grid [][]string, region string, start [2]int, visited map[string]bool) Area {
-	directions := [][]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-
-	queue := [][2]int{start}
-
-	visited[fmt.Sprintf("%d_%d", start[0], start[1])] = true
+func exploreRegion(garden *grid.Grid[rune], start int, visited []bool) Area {
+	region := garden.At(start)
+	queue := []int{start}
+	visited[start] = true
 
 	area := Area{
-		region:    region,
-		size:      1,
-		perimeter: 0,
-		corners:   0,
+		region: region,
+		size:   1,
 	}
 
-	areaCorners := make(map[[2]int]int)
-
 	for len(queue) > 0 {
 		cell := queue[0]
 		queue = queue[1:]
+		area.corners += countCellCorners(garden, cell)
 
-		for _, direction := range directions {
-			row := cell[0] + direction[0]
-			col := cell[1] + direction[1]
-			areaCorners[cell] = countCellCorners(grid, cell)
-
-			// within bounds
-			if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[0]) || grid[row][col] != region {
+		for _, direction := range point.Cardinal {
+			next, ok := garden.Step(cell, direction)
+			if !ok || garden.At(next) != region {
 				area.perimeter++
 				continue
 			}
 
-			key := fmt.Sprintf("%d_%d", row, col)
-
-			if !visited[key] && grid[row][col] == region {
-				queue = append(queue, [2]int{row, col})
-				visited[key] = true
+			if !visited[next] {
+				queue = append(queue, next)
+				visited[next] = true
 				area.size++
 			}
 		}
 	}
 
-	for _, corners := range areaCorners {
-		area.corners += corners
-	}
-
 	return area
 }
 
-func part1(grid [][]string) int {
-	visited := make(map[string]bool)
+func exploreRegions(garden *grid.Grid[rune]) []Area {
+	visited := make([]bool, len(garden.Cells))
 	var areas []Area
 
-	// traverse grid
-	for r := range grid {
-		for c := range grid[r] {
-			key := fmt.Sprintf("%d_%d", r, c)
-			if visited[key] {
-				continue
-			}
-
-			region := grid[r][c]
-			area := exploreRegion(grid, region, [2]int{r, c}, visited)
-
-			areas = append(areas, area)
+	for cell := range garden.Cells {
+		if !visited[cell] {
+			areas = append(areas, exploreRegion(garden, cell, visited))
 		}
 	}
+	return areas
+}
 
+func part1(garden *grid.Grid[rune]) (aoc.Answer, error) {
 	sum := 0
-	for _, area := range areas {
+	for _, area := range exploreRegions(garden) {
 		sum += area.size * area.perimeter
 	}
 
-	fmt.Println(sum)
-	return sum
+	return aoc.Int(sum), nil
 }
 
-func countCellCorners(grid [][]string, tile [2]int) int {
+func countCellCorners(garden *grid.Grid[rune], cell int) int {
 	corners := 0
-	region := grid[tile[0]][tile[1]]
-
-	// W WN N NE E ES S SW
-	directions := [][]int{{0, -1}, {-1, -1}, {-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}}
-
-	neighbors := make([]string, 8)
-	for i, direction := range directions {
-		row := tile[0] + direction[0]
-		col := tile[1] + direction[1]
+	region := garden.At(cell)
 
-		if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[0]) {
-			neighbors[i] = ""
-			continue
-		}
-
-		neighbors[i] = grid[row][col]
+	// neighbours clockwise from N: N NE E SE S SW W NW
+	sameRegion := make([]bool, len(point.All))
+	for i, direction := range point.All {
+		neighbour, ok := garden.Step(cell, direction)
+		sameRegion[i] = ok && garden.At(neighbour) == region
 	}
 
-	angleAndAdjacent := [4]map[string]string{}
-
-	angleAndAdjacent[0] = map[string]string{
-		"adjacentLeft":  neighbors[0],
-		"angle":         neighbors[1],
-		"adjacentRight": neighbors[2],
-	}
-	angleAndAdjacent[1] = map[string]string{
-		"adjacentLeft":  neighbors[2],
-		"angle":         neighbors[3],
-		"adjacentRight": neighbors[4],
-	}
-	angleAndAdjacent[2] = map[string]string{
-		"adjacentLeft":  neighbors[4],
-		"angle":         neighbors[5],
-		"adjacentRight": neighbors[6],
-	}
-	angleAndAdjacent[3] = map[string]string{
-		"adjacentLeft":  neighbors[6],
-		"angle":         neighbors[7],
-		"adjacentRight": neighbors[0],
-	}
-
-	for _, angle := range angleAndAdjacent {
-		// fmt.Println(tile, idx, angle)
+	for i := 0; i < len(sameRegion); i += 2 {
+		adjacentLeft := sameRegion[i]
+		angle := sameRegion[i+1]
+		adjacentRight := sameRegion[(i+2)%len(sameRegion)]
 
 		// outer corners (corner and adjacent are different regions)
-		if angle["angle"] != region && angle["adjacentLeft"] != region && angle["adjacentRight"] != region {
+		if !angle && !adjacentLeft && !adjacentRight {
 			corners++
 		}
 
 		// inner corners (corner is same region and adjacent are different region)
-		if (angle["angle"] == region && angle["adjacentLeft"] != region && angle["adjacentRight"] != region) || (angle["angle"] != region && angle["adjacentLeft"] == region && angle["adjacentRight"] == region) {
+		if (angle && !adjacentLeft && !adjacentRight) || (!angle && adjacentLeft && adjacentRight) {
 			corners++
 		}
 	}
@@ -168,40 +113,11 @@ func countCellCorners(grid [][]string, tile [2]int) int {
 	return corners
 }
 
-func part2(grid [][]string) int {
-	visited := make(map[string]bool)
-	var areas []Area
-
-	for r := range grid {
-		for c := range grid[r] {
-			key := fmt.Sprintf("%d_%d", r, c)
-			if visited[key] {
-				continue
-			}
-
-			region := grid[r][c]
-			area := exploreRegion(grid, region, [2]int{r, c}, visited)
-			areas = append(areas, area)
-		}
-	}
-
+func part2(garden *grid.Grid[rune]) (aoc.Answer, error) {
 	sumSides := 0
-	for _, area := range areas {
+	for _, area := range exploreRegions(garden) {
 		sumSides += area.size * area.corners
 	}
 
-	fmt.Println(sumSides)
-	return sumSides
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sumSides), nil
 }
@@ -1,81 +1,112 @@
-package main
+package day13
 
 import (
-	"fmt"
+	"aoc"
+	"aoc/mathx"
+	"aoc/point"
 	"math"
-	"os"
-	"path/filepath"
 	"regexp"
-	"strconv"
-	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 13, aoc.Solution[Arcade]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-type Vector struct {
-	x int
-	y int
-}
 type Button struct {
 	name   string
 	tokens int
-	move   Vector
+	move   point.Point
 }
 type Machine struct {
 	buttonA Button
 	buttonB Button
-	prize   Vector
+	prize   point.Point
+}
+
+var (
+	buttonALine = regexp.MustCompile(`Button A: X\+(\d+), Y\+(\d+)`)
+	buttonBLine = regexp.MustCompile(`Button B: X\+(\d+), Y\+(\d+)`)
+	prizeLine   = regexp.MustCompile(`Prize: X=(\d+), Y=(\d+)`)
+)
+
+func getLineCoords(line aoc.Line, re *regexp.Regexp) (point.Point, error) {
+	matches, err := line.Match(re)
+	if err != nil {
+		return point.Point{}, err
+	}
+	x, err := matches[0].Int()
+	if err != nil {
+		return point.Point{}, err
+	}
+	y, err := matches[1].Int()
+	if err != nil {
+		return point.Point{}, err
+	}
+	return point.Pt(x, y), nil
 }
 
-func getLineCoords(line string) Vector {
-	re := regexp.MustCompile(`X[+=](\d+), Y[+=](\d+)`)
-	matches := re.FindStringSubmatch(line)
-	x, _ := strconv.Atoi(matches[1])
-	y, _ := strconv.Atoi(matches[2])
-	return Vector{x: x, y: y}
+// Arcade holds the claw machines and how far the prizes really are in part 2.
+type Arcade struct {
+	machines []Machine
+	offset   int
 }
 
-func formatData(rows []string) []Machine {
+func formatData(in aoc.Input) (Arcade, error) {
+	offset, err := in.Int("offset", 10000000000000)
+	if err != nil {
+		return Arcade{}, err
+	}
+
 	machines := []Machine{}
-	for i := 0; i < len(rows); i += 4 {
-		lineButtonA := strings.Split(rows[i], "Button A: ")[1]
-		lineButtonB := strings.Split(rows[i+1], "Button B: ")[1]
-		linePrize := strings.Split(rows[i+2], "Prize: ")[1]
+	for _, rows := range in.Sections() {
+		if len(rows) != 3 {
+			return Arcade{}, rows[0].Errorf("expected two buttons and a prize, got %d lines", len(rows))
+		}
+		moveA, err := getLineCoords(rows[0], buttonALine)
+		if err != nil {
+			return Arcade{}, err
+		}
+		moveB, err := getLineCoords(rows[1], buttonBLine)
+		if err != nil {
+			return Arcade{}, err
+		}
+		prize, err := getLineCoords(rows[2], prizeLine)
+		if err != nil {
+			return Arcade{}, err
+		}
 		machine := Machine{
 			buttonA: Button{
 				name:   "A",
 				tokens: 3,
-				move:   getLineCoords(lineButtonA),
+				move:   moveA,
 			},
 			buttonB: Button{
 				name:   "B",
 				tokens: 1,
-				move:   getLineCoords(lineButtonB),
+				move:   moveB,
 			},
-			prize: getLineCoords(linePrize),
+			prize: prize,
 		}
 		machines = append(machines, machine)
 	}
-	return machines
+	return Arcade{machines, offset}, nil
 }
 
-func calculateMinimumTokens(buttons []Button, prize Vector, memo map[string]int) int {
-	key := fmt.Sprintf("%d_%d", prize.x, prize.y)
+func calculateMinimumTokens(buttons []Button, prize point.Point, memo map[point.Point]int) int {
+	key := prize
 	if value, ok := memo[key]; ok {
 		return value
 	}
 
-	if prize.x == 0 && prize.y == 0 {
+	if prize == (point.Point{}) {
 		return 0
 	}
 
-	if prize.x < 0 || prize.y < 0 {
+	if prize.X < 0 || prize.Y < 0 {
 		memo[key] = -1
 		return -1
 	}
@@ -83,11 +114,7 @@ func calculateMinimumTokens(buttons []Button, prize Vector, memo map[string]int)
 	minTokens := math.MaxInt32
 
 	for _, button := range buttons {
-		newTarget := Vector{
-			x: prize.x - button.move.x,
-			y: prize.y - button.move.y,
-		}
-		tokenCount := calculateMinimumTokens(buttons, newTarget, memo)
+		tokenCount := calculateMinimumTokens(buttons, prize.Sub(button.move), memo)
 
 		if tokenCount != -1 && tokenCount+1 < minTokens {
 			minTokens = tokenCount + button.tokens
@@ -103,97 +130,49 @@ func calculateMinimumTokens(buttons []Button, prize Vector, memo map[string]int)
 	return memo[key]
 }
 
-func part1(machines []Machine) int {
+func part1(arcade Arcade) (aoc.Answer, error) {
+	machines := arcade.machines
 	tokens := 0
 
-	for idx, machine := range machines {
-		if idx%40 == 0 {
-			fmt.Printf("Machine %d out of %d\n", idx+1, len(machines))
-		}
-
-		memo := make(map[string]int)
+	for _, machine := range machines {
+		memo := make(map[point.Point]int)
 		minMachineTokens := calculateMinimumTokens([]Button{machine.buttonA, machine.buttonB}, machine.prize, memo)
 		if minMachineTokens != -1 {
 			tokens += minMachineTokens
 		}
 	}
 
-	fmt.Println("Part 1:", tokens)
-	return tokens
+	return aoc.Int(tokens), nil
 }
 
 func calculateMinimumTokensMath(machine Machine) int {
 	// equations
-	// btnACount(ax) + btnBCount(bx) = px*constant
-	// btnACount(ay) + btnBCount(by) = py*constant
-	ax, ay := machine.buttonA.move.x, machine.buttonA.move.y
-	bx, by := machine.buttonB.move.x, machine.buttonB.move.y
-	px, py := machine.prize.x, machine.prize.y
-
-	determinant := float64(ax*by - ay*bx)
-
-	if determinant == 0 {
-		return -1 // impossible to solve
-	}
-
-	// solve for btnACount and btnBCount
-	// btnACount = (px*by - py*bx) / (ax*by - ay*bx)
-	// btnBCount = (ax*py - ay*px) / (ax*by - ay*bx)
-	btnACount := float64(px*by-py*bx) / determinant
-	btnBCount := float64(ax*py-ay*px) / determinant
+	// btnACount(ax) + btnBCount(bx) = px
+	// btnACount(ay) + btnBCount(by) = py
+	a, b := machine.buttonA.move, machine.buttonB.move
+	counts, ok := mathx.SolveInt([][]int{{a.X, b.X}, {a.Y, b.Y}}, []int{machine.prize.X, machine.prize.Y})
 
 	// legit counts (must be positive and full numbers)
-	if btnACount < 0 || btnBCount < 0 || math.Floor(btnACount) != btnACount || math.Floor(btnBCount) != btnBCount {
+	if !ok || counts[0] < 0 || counts[1] < 0 {
 		return -1
 	}
 
-	// calculate the total tokens
 	// tokens = btnACount * btnA.tokens + btnBCount * btnB.tokens
-	return int(float64(machine.buttonA.tokens)*btnACount + float64(machine.buttonB.tokens)*btnBCount)
+	return counts[0]*machine.buttonA.tokens + counts[1]*machine.buttonB.tokens
 }
 
-func part2(machines []Machine) int {
+func part2(arcade Arcade) (aoc.Answer, error) {
+	machines, constant := arcade.machines, arcade.offset
 	tokens := 0
 
-	for run := 0; run < 2; run++ {
-		for idx, machine := range machines {
-			if idx%40 == 0 {
-				fmt.Printf("Machine %d out of %d\n", idx+1, len(machines))
-			}
-
-			constant := 0
-			if run%2 != 0 {
-				constant = 10000000000000
-			}
-
-			newPrizeX := constant + machine.prize.x
-			newPrizeY := constant + machine.prize.y
-
-			machine.prize = Vector{
-				x: newPrizeX,
-				y: newPrizeY,
-			}
-
-			minMachineTokens := calculateMinimumTokensMath(machine)
-			if minMachineTokens != -1 {
-				tokens += minMachineTokens
-			}
-		}
-
-		fmt.Printf("Part %d: %d\n", run+1, tokens)
-	}
-
-	return tokens
-}
+	for _, machine := range machines {
+		machine.prize = machine.prize.Add(point.Pt(constant, constant))
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+		minMachineTokens := calculateMinimumTokensMath(machine)
+		if minMachineTokens != -1 {
+			tokens += minMachineTokens
+		}
 	}
 
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(tokens), nil
 }
@@ -1,61 +1,64 @@
-package main
+package day14
 
 import (
+	"aoc"
+	"aoc/mathx"
+	"aoc/point"
+	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"regexp"
 	"strconv"
-	"strings"
-	"time"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 14, aoc.Solution[Room]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func parseRobot(line string) Robot {
-	// extract vectors from line such as "p=0,4 v=3,-3"
-	re := regexp.MustCompile(`p=([-\d]+),([-\d]+) v=([-\d]+),([-\d]+)`)
-	matches := re.FindStringSubmatch(line)
-	px, _ := strconv.Atoi(matches[1])
-	py, _ := strconv.Atoi(matches[2])
-	vx, _ := strconv.Atoi(matches[3])
-	vy, _ := strconv.Atoi(matches[4])
-
-	return Robot{
-		position: Vector{px, py},
-		move:     Vector{vx, vy},
+func parseRobot(line aoc.Line) (Robot, error) {
+	// a line such as "p=0,4 v=3,-3"
+	var robot Robot
+	if err := line.Scan("p=%d,%d v=%d,%d", &robot.position.X, &robot.position.Y, &robot.move.X, &robot.move.Y); err != nil {
+		return Robot{}, err
 	}
+	return robot, nil
 }
 
-func formatData(data []string) map[Vector][]*Robot {
-	robots := make(map[Vector][]*Robot)
+func formatData(in aoc.Input) (Room, error) {
+	width, err := in.Int("width", 101)
+	if err != nil {
+		return Room{}, err
+	}
+	height, err := in.Int("height", 103)
+	if err != nil {
+		return Room{}, err
+	}
 
-	for _, row := range data {
-		if row == "" {
-			continue
+	robots := make(map[point.Point][]*Robot)
+	for _, row := range in.Rows() {
+		robot, err := parseRobot(row)
+		if err != nil {
+			return Room{}, err
 		}
-		robot := parseRobot(row)
 		robots[robot.position] = append(robots[robot.position], &robot)
 	}
-	return robots
+	return Room{robots, width, height}, nil
 }
 
-type Vector struct {
-	x, y int
+// Room is the robots' bathroom, which is smaller in the puzzle example.
+type Room struct {
+	robots        map[point.Point][]*Robot
+	width, height int
 }
+
 type Robot struct {
-	position Vector
-	move     Vector
+	position point.Point
+	move     point.Point
 }
 
-func renderGrid(robots map[Vector][]*Robot, width int, height int, hideMid bool) [][]string {
+func renderGrid(robots map[point.Point][]*Robot, width int, height int, hideMid bool) [][]string {
 	grid := make([][]string, height)
 
 	for r := range grid {
@@ -67,7 +70,7 @@ func renderGrid(robots map[Vector][]*Robot, width int, height int, hideMid bool)
 				continue
 			}
 
-			v := Vector{c, r}
+			v := point.Pt(c, r)
 			if _, ok := robots[v]; ok {
 				grid[r][c] = strconv.Itoa(len(robots[v]))
 			} else {
@@ -80,43 +83,39 @@ func renderGrid(robots map[Vector][]*Robot, width int, height int, hideMid bool)
 	return grid
 }
 
-func calculateNextPositions(robotsMap map[Vector][]*Robot, nbOfSeconds int, width int, height int) map[Vector][]*Robot {
-	nextRobotsMap := make(map[Vector][]*Robot)
+func calculateNextPositions(robotsMap map[point.Point][]*Robot, nbOfSeconds int, width int, height int) map[point.Point][]*Robot {
+	nextRobotsMap := make(map[point.Point][]*Robot)
 	for _, robots := range robotsMap {
 		for _, robot := range robots {
-			newPosition := Vector{
-				x: ((robot.position.x+(robot.move.x*nbOfSeconds))%width + width) % width,
-				y: ((robot.position.y+(robot.move.y*nbOfSeconds))%height + height) % height,
-			}
+			newPosition := robot.position.Add(robot.move.Scale(nbOfSeconds)).Wrap(width, height)
 			nextRobotsMap[newPosition] = append(nextRobotsMap[newPosition], robot)
 		}
 	}
 	return nextRobotsMap
 }
 
-func getQuadrants(nextRobotsMap map[Vector][]*Robot, midCol int, midRow int) map[string][]*Robot {
+func getQuadrants(nextRobotsMap map[point.Point][]*Robot, midCol int, midRow int) map[string][]*Robot {
 	quadrants := make(map[string][]*Robot)
 
 	for v, robots := range nextRobotsMap {
-		if v.x < midCol && v.y < midRow {
+		if v.X < midCol && v.Y < midRow {
 			quadrants["topLeft"] = append(quadrants["topLeft"], robots...)
 		}
-		if v.x > midCol && v.y < midRow {
+		if v.X > midCol && v.Y < midRow {
 			quadrants["topRight"] = append(quadrants["topRight"], robots...)
 		}
-		if v.x < midCol && v.y > midRow {
+		if v.X < midCol && v.Y > midRow {
 			quadrants["bottomLeft"] = append(quadrants["bottomLeft"], robots...)
 		}
-		if v.x > midCol && v.y > midRow {
+		if v.X > midCol && v.Y > midRow {
 			quadrants["bottomRight"] = append(quadrants["bottomRight"], robots...)
 		}
 	}
 	return quadrants
 }
 
-func part1(robotsMap map[Vector][]*Robot) int {
-	width := 101
-	height := 103
+func part1(room Room) (aoc.Answer, error) {
+	robotsMap, width, height := room.robots, room.width, room.height
 	nbOfSeconds := 100
 
 	nextRobotsMap := calculateNextPositions(robotsMap, nbOfSeconds, width, height)
@@ -124,7 +123,9 @@ func part1(robotsMap map[Vector][]*Robot) int {
 	midCol := width / 2
 	midRow := height / 2
 
-	renderGrid(nextRobotsMap, width, height, true)
+	if aoc.Visual() {
+		renderGrid(nextRobotsMap, width, height, true)
+	}
 
 	quadrants := getQuadrants(nextRobotsMap, midCol, midRow)
 
@@ -137,58 +138,54 @@ func part1(robotsMap map[Vector][]*Robot) int {
 		result *= len(robots)
 	}
 
-	fmt.Println("part 1:", result)
-	return result
+	return aoc.Int(result), nil
 }
 
-func part2(robotsMap map[Vector][]*Robot) {
-	if err := os.MkdirAll("dump", 0755); err != nil {
-		log.Fatal(err)
-	}
-
-	width := 101
-	height := 103
-
-	for i := 8000; i <= 60*60*8; i++ {
-		nbOfSeconds := i + 1
-		nextRobotsMap := calculateNextPositions(robotsMap, nbOfSeconds, width, height)
-
-		quadrants := getQuadrants(nextRobotsMap, width/2, height/2)
-
-		robotCountPerQuadrant := []int{
-			len(quadrants["topLeft"]),
-			len(quadrants["topRight"]),
-			len(quadrants["bottomLeft"]),
-			len(quadrants["bottomRight"]),
-		}
-
-		renderGrid(nextRobotsMap, width, height, false)
-		fmt.Println("Seconds:", nbOfSeconds)
-		time.Sleep(150 * time.Millisecond)
-
-		maxRobots := 0
-		for _, count := range robotCountPerQuadrant {
-			if count > maxRobots {
-				maxRobots = count
+// bunched is the second, between 0 and period-1, when the robots are closest
+// together along the axis coordinate measures, which comes back every period
+// seconds. Closest is the smallest variance, compared as n² times it so it
+// stays an integer.
+func bunched(robotsMap map[point.Point][]*Robot, period int, coordinate func(robot *Robot, seconds int) int) int {
+	best, bestSpread := 0, -1
+	for seconds := range period {
+		n, sum, sumSq := 0, 0, 0
+		for _, robots := range robotsMap {
+			for _, robot := range robots {
+				c := coordinate(robot, seconds)
+				n++
+				sum += c
+				sumSq += c * c
 			}
 		}
-		if maxRobots > len(robotsMap)/2 {
-			renderGrid(nextRobotsMap, width, height, false)
-			timeFormatted := fmt.Sprintf("%02dh:%02dm:%02ds", nbOfSeconds/3600, (nbOfSeconds/60)%60, nbOfSeconds%60)
-			fmt.Printf("Part 2: %d (%s)", nbOfSeconds, timeFormatted)
-			break
+		if spread := n*sumSq - sum*sum; bestSpread < 0 || spread < bestSpread {
+			best, bestSpread = seconds, spread
 		}
 	}
+	return best
 }
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+func part2(room Room) (aoc.Answer, error) {
+	withVisual := aoc.Visual()
+	robotsMap, width, height := room.robots, room.width, room.height
+
+	// along X the robots are back where they started every width seconds and
+	// along Y every height seconds, so the tree, where they bunch up on both
+	// axes at once, is where the two cycles line up
+	bunchedX := bunched(robotsMap, width, func(robot *Robot, seconds int) int {
+		return robot.position.Add(robot.move.Scale(seconds)).Wrap(width, height).X
+	})
+	bunchedY := bunched(robotsMap, height, func(robot *Robot, seconds int) int {
+		return robot.position.Add(robot.move.Scale(seconds)).Wrap(width, height).Y
+	})
+	nbOfSeconds, _, ok := mathx.CRT([]int{bunchedX, bunchedY}, []int{width, height})
+	if !ok {
+		return aoc.Answer{}, errors.New("no christmas tree in sight")
 	}
 
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	if withVisual {
+		renderGrid(calculateNextPositions(robotsMap, nbOfSeconds, width, height), width, height, false)
+		timeFormatted := fmt.Sprintf("%02dh:%02dm:%02ds", nbOfSeconds/3600, (nbOfSeconds/60)%60, nbOfSeconds%60)
+		fmt.Printf("Found after %d seconds (%s)\n", nbOfSeconds, timeFormatted)
+	}
+	return aoc.Int(nbOfSeconds), nil
 }
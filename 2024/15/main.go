@@ -1,68 +1,67 @@
-package main
+package day15
 
 import (
+	"aoc"
+	"aoc/point"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/eiannone/keyboard"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 15, aoc.Solution[Inputs]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
 type Inputs struct {
 	grid  [][]string
-	moves []Vector
+	moves []point.Point
 }
 
-func formatData(rows []string) Inputs {
+func formatData(in aoc.Input) (Inputs, error) {
+	sections, err := in.SectionsN(2)
+	if err != nil {
+		return Inputs{}, err
+	}
 	grid := [][]string{}
-	moves := []Vector{}
+	moves := []point.Point{}
 
-	parseGrid := true
-	for _, row := range rows {
-		if row == "" {
-			parseGrid = false
-			continue
-		}
-		if parseGrid {
-			grid = append(grid, strings.Split(row, ""))
-		}
-		if !parseGrid {
-			remainingRowsAsString := strings.Join(rows, "")
-			for _, char := range remainingRowsAsString {
-				switch char {
-				case '^':
-					moves = append(moves, Vector{0, -1})
-				case 'v':
-					moves = append(moves, Vector{0, 1})
-				case '<':
-					moves = append(moves, Vector{-1, 0})
-				case '>':
-					moves = append(moves, Vector{1, 0})
-				}
+	gridRows, err := aoc.GridOf(in, sections[0], "#.O@")
+	if err != nil {
+		return Inputs{}, err
+	}
+	for _, row := range gridRows {
+		grid = append(grid, strings.Split(row.Text, ""))
+	}
+
+	for _, row := range sections[1] {
+		for _, char := range row.Runes() {
+			switch char.Text {
+			case "^":
+				moves = append(moves, point.Up)
+			case "v":
+				moves = append(moves, point.Down)
+			case "<":
+				moves = append(moves, point.Left)
+			case ">":
+				moves = append(moves, point.Right)
+			default:
+				return Inputs{}, char.Errorf("unexpected move %q", char)
 			}
-			break
 		}
 	}
-	return Inputs{grid, moves}
-}
-
-type Vector struct {
-	x, y int
+	return Inputs{grid, moves}, nil
 }
 
 type Entity struct {
-	start Vector
-	end   Vector
+	start point.Point
+	end   point.Point
 }
 
 type Game struct {
@@ -71,33 +70,33 @@ type Game struct {
 	boxes       map[Entity]bool
 	grid        [][]string
 	isScaled    bool
-	playerMoves []Vector
+	playerMoves []point.Point
 }
 
-func (g *Game) isWall(v Vector) bool {
+func (g *Game) isWall(v point.Point) bool {
 	for wall := range g.walls {
-		if v.x >= wall.start.x && v.x <= wall.end.x &&
-			v.y >= wall.start.y && v.y <= wall.end.y {
+		if v.X >= wall.start.X && v.X <= wall.end.X &&
+			v.Y >= wall.start.Y && v.Y <= wall.end.Y {
 			return true
 		}
 	}
 	return false
 }
 
-func (g *Game) isBox(v Vector) bool {
+func (g *Game) isBox(v point.Point) bool {
 	for box := range g.boxes {
-		if v.y == box.start.y &&
-			v.x >= box.start.x && v.x <= box.end.x {
+		if v.Y == box.start.Y &&
+			v.X >= box.start.X && v.X <= box.end.X {
 			return true
 		}
 	}
 	return false
 }
 
-func (g *Game) getBox(v Vector) Entity {
+func (g *Game) getBox(v point.Point) Entity {
 	for box := range g.boxes {
-		if v.y == box.start.y && // Same row
-			v.x >= box.start.x && v.x <= box.end.x { // Within box width
+		if v.Y == box.start.Y && // Same row
+			v.X >= box.start.X && v.X <= box.end.X { // Within box width
 			return box
 		}
 	}
@@ -121,16 +120,16 @@ func (g *Game) render() [][]string {
 
 	// place walls
 	for wall := range g.walls {
-		board[wall.start.y][wall.start.x] = "#"
+		board[wall.start.Y][wall.start.X] = "#"
 	}
 
 	// place boxes
 	for box := range g.boxes {
-		board[box.start.y][box.start.x] = "O"
+		board[box.start.Y][box.start.X] = "O"
 	}
 
 	// place robot
-	board[g.robot.start.y][g.robot.start.x] = "@"
+	board[g.robot.start.Y][g.robot.start.X] = "@"
 
 	// print board
 	for _, row := range board {
@@ -141,7 +140,7 @@ func (g *Game) render() [][]string {
 }
 
 func isForwardBox(box Entity) bool {
-	return box.start.x < box.end.x
+	return box.start.X < box.end.X
 }
 
 func (g *Game) renderScale() [][]string {
@@ -157,23 +156,23 @@ func (g *Game) renderScale() [][]string {
 
 	// place walls
 	for wall := range g.walls {
-		r := wall.start.y
-		board[r][wall.start.x] = "#"
-		board[r][wall.end.x] = "#"
+		r := wall.start.Y
+		board[r][wall.start.X] = "#"
+		board[r][wall.end.X] = "#"
 	}
 
 	// place boxes
 	for box := range g.boxes {
 		if isForwardBox(box) {
-			r := box.start.y
-			board[r][box.start.x] = "["
-			board[r][box.end.x] = "]"
+			r := box.start.Y
+			board[r][box.start.X] = "["
+			board[r][box.end.X] = "]"
 		}
 	}
 
 	// place robot
-	r := g.robot.start.y
-	board[r][g.robot.start.x] = "@"
+	r := g.robot.start.Y
+	board[r][g.robot.start.X] = "@"
 
 	for _, row := range board {
 		fmt.Println(strings.Join(row, ""))
@@ -194,23 +193,23 @@ func (g *Game) setup(grid [][]string) *Game {
 			}
 
 			entity := Entity{
-				Vector{baseX, r},
-				Vector{baseX, r},
+				point.Pt(baseX, r),
+				point.Pt(baseX, r),
 			}
 
 			switch col {
 			case "#":
 				if g.isScaled {
-					entity.end = Vector{baseX + 1, r}
+					entity.end = point.Pt(baseX+1, r)
 				}
 				g.walls[entity] = true
 			case "@":
 				g.robot = entity
 			case "O", "[":
 				if g.isScaled {
-					entity.end = Vector{baseX + 1, r}
-					forward := Entity{Vector{baseX, r}, Vector{baseX + 1, r}}
-					backward := Entity{Vector{baseX + 1, r}, Vector{baseX, r}}
+					entity.end = point.Pt(baseX+1, r)
+					forward := Entity{point.Pt(baseX, r), point.Pt(baseX+1, r)}
+					backward := Entity{point.Pt(baseX+1, r), point.Pt(baseX, r)}
 					g.boxes[forward] = true
 					g.boxes[backward] = true
 				} else {
@@ -222,10 +221,10 @@ func (g *Game) setup(grid [][]string) *Game {
 	return g
 }
 
-func (g *Game) moveRobot(move Vector) bool {
+func (g *Game) moveRobot(move point.Point) bool {
 	nextPosition := Entity{
-		Vector{g.robot.start.x + move.x, g.robot.start.y + move.y},
-		Vector{g.robot.end.x + move.x, g.robot.end.y + move.y},
+		g.robot.start.Add(move),
+		g.robot.end.Add(move),
 	}
 
 	if g.isWall(nextPosition.start) {
@@ -238,9 +237,9 @@ func (g *Game) moveRobot(move Vector) bool {
 	}
 
 	// check for backwards or forwards cell
-	if move.x != 0 {
+	if move.X != 0 {
 		checkPosition := nextPosition.start
-		if move.x > 0 {
+		if move.X > 0 {
 			checkPosition = nextPosition.end
 		}
 		if g.isBox(checkPosition) {
@@ -252,9 +251,9 @@ func (g *Game) moveRobot(move Vector) bool {
 	}
 
 	// check for up and down cell
-	if move.y != 0 {
+	if move.Y != 0 {
 		checkPosition := nextPosition.start
-		if move.y > 0 {
+		if move.Y > 0 {
 			checkPosition = nextPosition.end
 		}
 		if g.isBox(checkPosition) {
@@ -270,23 +269,23 @@ func (g *Game) moveRobot(move Vector) bool {
 	return true
 }
 
-func (g *Game) moveBox(box Entity, direction Vector, depth int) bool {
+func (g *Game) moveBox(box Entity, direction point.Point, depth int) bool {
 	if depth > max(len(g.grid), len(g.grid[0])) {
 		return false
 	}
 
 	nextPosition := Entity{
-		Vector{box.start.x + direction.x, box.start.y + direction.y},
-		Vector{box.end.x + direction.x, box.end.y + direction.y},
+		box.start.Add(direction),
+		box.end.Add(direction),
 	}
 
 	if g.isWall(nextPosition.start) || g.isWall(nextPosition.end) {
 		return false
 	}
 
-	if direction.x != 0 {
+	if direction.X != 0 {
 		checkPosition := nextPosition.start
-		if direction.x > 0 {
+		if direction.X > 0 {
 			checkPosition = nextPosition.end
 		}
 		if g.isBox(checkPosition) {
@@ -297,8 +296,8 @@ func (g *Game) moveBox(box Entity, direction Vector, depth int) bool {
 		}
 	}
 
-	if direction.y != 0 {
-		checkPositions := []Vector{nextPosition.start, nextPosition.end}
+	if direction.Y != 0 {
+		checkPositions := []point.Point{nextPosition.start, nextPosition.end}
 		for _, checkPosition := range checkPositions {
 			if g.isBox(checkPosition) {
 				adjacentBox := g.getBox(checkPosition)
@@ -316,40 +315,53 @@ func (g *Game) moveBox(box Entity, direction Vector, depth int) bool {
 	return true
 }
 
-func part1(inputs Inputs) {
+func part1(inputs Inputs) (aoc.Answer, error) {
+	withVisual := aoc.Visual()
 	game := Game{}
 	game.setup(inputs.grid)
-	game.render()
+	if withVisual {
+		game.render()
+	}
 
 	for _, move := range inputs.moves {
 		game.moveRobot(move)
 	}
 
-	game.render()
+	if withVisual {
+		game.render()
+	}
 
 	sum := 0
 	for box := range game.boxes {
-		sum += (box.start.y*100 + box.start.x)
+		sum += (box.start.Y*100 + box.start.X)
 	}
 
-	fmt.Println("Part 1:", sum)
+	return aoc.Int(sum), nil
 }
 
-func part2(inputs Inputs) {
+func part2(inputs Inputs) (aoc.Answer, error) {
+	withVisual := aoc.Visual()
 	game := Game{isScaled: true}
 	game.setup(inputs.grid)
-	game.render()
+	if withVisual {
+		game.render()
+	}
 
 	for m, move := range inputs.moves {
+		if !withVisual {
+			game.moveRobot(move)
+			continue
+		}
+
 		var moveStr string
 		switch move {
-		case Vector{0, -1}:
+		case point.Up:
 			moveStr = "^"
-		case Vector{0, 1}:
+		case point.Down:
 			moveStr = "v"
-		case Vector{-1, 0}:
+		case point.Left:
 			moveStr = "<"
-		case Vector{1, 0}:
+		case point.Right:
 			moveStr = ">"
 		}
 
@@ -369,38 +381,19 @@ func part2(inputs Inputs) {
 	for box := range game.boxes {
 		// count only forwards boxes
 		if isForwardBox(box) {
-			sum += (box.start.y*100 + box.start.x)
+			sum += (box.start.Y*100 + box.start.X)
 		}
 	}
 
-	fmt.Println("Part 2:", sum)
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
 
 // interactive mode
-func main2() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-	formattedData := formatData(data)
-
+func interactive(inputs Inputs) {
 	fmt.Println("Interactive mode:")
 
 	game := Game{isScaled: true}
-	game.setup(formattedData.grid)
+	game.setup(inputs.grid)
 
 	fmt.Print("\033[H\033[2J")
 	fmt.Println("Use WASD to move, R to reset and Q to quit")
@@ -414,7 +407,7 @@ func main2() {
 	}
 }
 
-func (game *Game) getPlayerMove() Vector {
+func (game *Game) getPlayerMove() point.Point {
 	if err := keyboard.Open(); err != nil {
 		panic(err)
 	}
@@ -422,31 +415,29 @@ func (game *Game) getPlayerMove() Vector {
 
 	char, _, err := keyboard.GetKey()
 	if err != nil {
-		return Vector{0, 0}
+		return point.Point{}
 	}
 
 	switch char {
 	case 'w', 'W':
-		return Vector{0, -1}
+		return point.Up
 	case 's', 'S':
-		return Vector{0, 1}
+		return point.Down
 	case 'a', 'A':
-		return Vector{-1, 0}
+		return point.Left
 	case 'd', 'D':
-		return Vector{1, 0}
+		return point.Right
 	case 'q', 'Q':
 		os.Exit(1)
-		return Vector{0, 0}
+		return point.Point{}
 	case 'r', 'R':
 		game.reload()
-		return Vector{0, 0}
+		return point.Point{}
 	default:
-		return Vector{0, 0}
+		return point.Point{}
 	}
 }
 
 func (game *Game) reload() {
-	data, _ := readData()
-	formattedData := formatData(data)
-	game.setup(formattedData.grid)
+	game.setup(game.grid)
 }
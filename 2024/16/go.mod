@@ -1,3 +0,0 @@
-module main
-
-go 1.23.3
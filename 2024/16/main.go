@@ -1,187 +1,104 @@
-package main
+package day16
 
 import (
-	"container/heap"
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"aoc"
+	"aoc/point"
+	"aoc/search"
+	"errors"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
-}
-
-type Vector struct {
-	x, y int
+func init() {
+	aoc.Register(2024, 16, aoc.Solution[Maze]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
 type Maze struct {
-	grid      [][]string
-	walls     map[Vector]bool
-	start     Vector
-	end       Vector
-	score     int
-	bestPaths map[Vector]bool
+	grid  [][]string
+	walls map[point.Point]bool
+	start point.Point
+	end   point.Point
 }
 
-func formatData(rows []string) Maze {
+func formatData(in aoc.Input) (Maze, error) {
+	rows, err := in.Grid("#.SE")
+	if err != nil {
+		return Maze{}, err
+	}
 	maze := Maze{
-		grid:      make([][]string, len(rows)),
-		walls:     make(map[Vector]bool),
-		score:     int(^uint(0) >> 1),
-		bestPaths: make(map[Vector]bool),
+		grid:  make([][]string, len(rows)),
+		walls: make(map[point.Point]bool),
 	}
+	starts, ends := 0, 0
 	for r, row := range rows {
-		maze.grid[r] = make([]string, len(row))
-		for c, char := range row {
-			v := Vector{c, r}
+		maze.grid[r] = make([]string, len(row.Text))
+		for c, char := range row.Text {
+			v := point.Pt(c, r)
 			switch char {
 			case '#':
 				maze.walls[v] = true
 			case 'S':
 				maze.start = v
+				starts++
 			case 'E':
 				maze.end = v
+				ends++
 			}
 			maze.grid[r][c] = string(char)
 		}
 	}
-	return maze
+	if starts != 1 {
+		return Maze{}, in.Errorf("expected one start, got %d", starts)
+	}
+	if ends != 1 {
+		return Maze{}, in.Errorf("expected one end, got %d", ends)
+	}
+	return maze, nil
 }
 
-type QueueItem struct {
-	position  Vector
-	direction int
-	score     int
-	path      []Vector
-}
-type Queue []*QueueItem
+var errNoPath = errors.New("no path from the start to the end")
 
-func (queue *Queue) Pop() interface{} {
-	item := (*queue)[0]
-	*queue = (*queue)[1:]
-	return item
+// state is where the reindeer is and which way it faces, turning on the spot
+// being a move to another state of the same tile.
+type state struct {
+	position  point.Point
+	direction point.Point
 }
-func (queue *Queue) Push(item interface{}) { *queue = append(*queue, item.(*QueueItem)) }
-func (queue Queue) Swap(a, b int)          { queue[a], queue[b] = queue[b], queue[a] }
-func (queue Queue) Len() int               { return len(queue) }
-
-// sort queue by lowest score
-func (queue Queue) Less(a, b int) bool { return queue[a].score < queue[b].score }
-
-func (maze *Maze) solve() int {
-	// North, East, South, West
-	directions := []Vector{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
-	visited := make(map[string]int)
-
-	queue := &Queue{}
-	heap.Init(queue)
-
-	heap.Push(queue, &QueueItem{
-		position:  maze.start,
-		direction: 1,
-		score:     0,
-		path:      []Vector{maze.start},
-	})
-
-	for queue.Len() > 0 {
-		current := queue.Pop().(*QueueItem)
-
-		if current.score > maze.score {
-			continue
-		}
 
-		key := fmt.Sprintf("%d_%d_%d", current.position.x, current.position.y, current.direction)
-		if _, exist := visited[key]; exist {
-			if visited[key] < current.score {
-				continue
-			}
-		}
-		visited[key] = current.score
-
-		if current.position == maze.end {
-			// reset for new best score
-			if current.score < maze.score {
-				maze.bestPaths = make(map[Vector]bool)
-				maze.score = current.score
-			}
-			// mark best path
-			for _, tile := range current.path {
-				maze.bestPaths[tile] = true
-			}
-			continue
-		}
-
-		// forward - left - right
-		nextPosition := Vector{
-			current.position.x + directions[current.direction].x,
-			current.position.y + directions[current.direction].y,
-		}
-		if !maze.walls[nextPosition] && nextPosition.x >= 0 && nextPosition.y >= 0 &&
-			nextPosition.y < len(maze.grid) && nextPosition.x < len(maze.grid[0]) {
-			newPath := make([]Vector, len(current.path))
-			copy(newPath, current.path)
-			newPath = append(newPath, nextPosition)
-			heap.Push(queue, &QueueItem{
-				position:  nextPosition,
-				direction: current.direction,
-				score:     current.score + 1,
-				path:      newPath,
-			})
-		}
-
-		leftDir := (current.direction + 3) % 4
-		newPathLeft := make([]Vector, len(current.path))
-		copy(newPathLeft, current.path)
-		heap.Push(queue, &QueueItem{
-			position:  current.position,
-			direction: leftDir,
-			score:     current.score + 1000,
-			path:      newPathLeft,
-		})
-
-		rightDir := (current.direction + 1) % 4
-		newPathRight := make([]Vector, len(current.path))
-		copy(newPathRight, current.path)
-		heap.Push(queue, &QueueItem{
-			position:  current.position,
-			direction: rightDir,
-			score:     current.score + 1000,
-			path:      newPathRight,
-		})
+func (maze Maze) next(current state) []search.Edge[state] {
+	edges := []search.Edge[state]{
+		{To: state{current.position, current.direction.TurnLeft()}, Cost: 1000},
+		{To: state{current.position, current.direction.TurnRight()}, Cost: 1000},
 	}
-
-	if maze.score == int(^uint(0)>>1) {
-		maze.score = -1
+	forward := current.position.Add(current.direction)
+	if !maze.walls[forward] && forward.In(len(maze.grid[0]), len(maze.grid)) {
+		edges = append(edges, search.Edge[state]{To: state{forward, current.direction}, Cost: 1})
 	}
-	return maze.score
+	return edges
 }
 
-func part1(maze Maze) int {
-	maze.solve()
-	fmt.Println(maze.score)
-	return maze.score
+func (maze Maze) atEnd(current state) bool {
+	return current.position == maze.end
 }
 
-func part2(maze Maze) int {
-	maze.solve()
-	fmt.Println(len(maze.bestPaths))
-	return len(maze.bestPaths)
+func part1(maze Maze) (aoc.Answer, error) {
+	path, ok := search.Dijkstra(state{maze.start, point.Right}, maze.next, maze.atEnd)
+	if !ok {
+		return aoc.Answer{}, errNoPath
+	}
+	return aoc.Int(path.Cost), nil
 }
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+func part2(maze Maze) (aoc.Answer, error) {
+	tree, ok := search.AllShortest(state{maze.start, point.Right}, maze.next, maze.atEnd)
+	if !ok {
+		return aoc.Answer{}, errNoPath
 	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	tiles := make(map[point.Point]bool)
+	for _, s := range tree.States() {
+		tiles[s.position] = true
+	}
+	return aoc.Int(len(tiles)), nil
 }
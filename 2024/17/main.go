@@ -1,19 +1,18 @@
-package main
+package day17
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
+	"aoc"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 17, aoc.Solution[System]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
 type System struct {
@@ -21,33 +20,47 @@ type System struct {
 	program   []int
 }
 
-func formatData(rows []string) System {
+var registerName = regexp.MustCompile(`^Register [ABC]$`)
+
+func formatData(in aoc.Input) (System, error) {
+	rows := in.Rows()
 	system := System{
 		registers: make(map[string]int),
 		program:   []int{},
 	}
 
 	for _, row := range rows {
-		if strings.Contains(row, "Register") {
-			parts := strings.Split(row, ": ")
-			registerName := parts[0]
-			registerValue, _ := strconv.Atoi(parts[1])
-			system.registers[registerName] = registerValue
-		}
-
-		if strings.Contains(row, "Program") {
-			parts := strings.Split(row, ": ")
-			program := strings.Split(parts[1], ",")
-
-			system.program = make([]int, len(program))
-			for i, v := range program {
-				system.program[i], _ = strconv.Atoi(v)
+		switch {
+		case row.Text == "":
+			continue
+		case strings.HasPrefix(row.Text, "Register "):
+			name, value, err := row.Cut(": ")
+			if err != nil {
+				return System{}, err
 			}
-
+			if !registerName.MatchString(name.Text) {
+				return System{}, name.Errorf("expected register A, B or C, got %q", name)
+			}
+			registerValue, err := value.Int()
+			if err != nil {
+				return System{}, err
+			}
+			system.registers[name.Text] = registerValue
+		case strings.HasPrefix(row.Text, "Program: "):
+			program, _ := row.CutPrefix("Program: ")
+			var err error
+			if system.program, err = program.Ints(","); err != nil {
+				return System{}, err
+			}
+		default:
+			return System{}, row.Errorf("expected a register or the program, got %q", row)
 		}
 	}
+	if len(system.program) == 0 {
+		return System{}, in.Errorf("no program")
+	}
 
-	return system
+	return system, nil
 }
 
 func evaluate(system System, opcode int, operand int) ([]string, bool) {
@@ -124,12 +137,11 @@ func execute(inputs System) []string {
 	return outputs
 }
 
-func part1(inputs System) string {
+func part1(inputs System) (aoc.Answer, error) {
 	outputs := execute(inputs)
 
 	result := strings.Join(outputs, ",")
-	fmt.Println("Part 1:", result)
-	return result
+	return aoc.String(result), nil
 }
 
 func findMinRegisterValue(sys *System, programIndex int, result int) int {
@@ -189,23 +201,8 @@ func findMinRegisterValue(sys *System, programIndex int, result int) int {
 	return -1
 }
 
-func part2(inputs System) int {
+func part2(inputs System) (aoc.Answer, error) {
 	programLength := len(inputs.program) - 1
 	result := findMinRegisterValue(&inputs, programLength, inputs.program[programLength])
-	fmt.Println("Part 2:", result)
-	return result
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-
-	formattedData = formatData(data)
-	part2(formattedData)
+	return aoc.Int(result), nil
 }
@@ -1,78 +1,99 @@
-package main
+package day18
 
 import (
-	"container/heap"
+	"aoc"
+	"aoc/point"
+	"aoc/search"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 18, aoc.Solution[Memory]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-type Vector struct {
-	x, y int
-}
+func formatData(in aoc.Input) (Memory, error) {
+	size, err := in.Int("size", 71)
+	if err != nil {
+		return Memory{}, err
+	}
+	fallen, err := in.Int("bytes", 1024)
+	if err != nil {
+		return Memory{}, err
+	}
 
-func formatData(rows []string) []Vector {
-	walls := make([]Vector, len(rows))
+	rows := in.Rows()
+	walls := make([]point.Point, len(rows))
 	for i, row := range rows {
-		position := strings.Split(row, ",")
-		x, _ := strconv.Atoi(position[0])
-		y, _ := strconv.Atoi(position[1])
-		walls[i] = Vector{x, y}
+		wall, err := point.Parse(row)
+		if err != nil {
+			return Memory{}, err
+		}
+		if !wall.In(size, size) {
+			return Memory{}, row.Errorf("%s is outside the %dx%d memory space", wall, size, size)
+		}
+		walls[i] = wall
+	}
+	if fallen < 0 || fallen > len(walls) {
+		return Memory{}, rows[len(rows)-1].Errorf("%d bytes have fallen, the input lists %d", fallen, len(walls))
 	}
-	return walls
+	return Memory{walls, size, fallen}, nil
+}
+
+// Memory is the falling bytes along with the grid size and how many bytes
+// have fallen for part 1, both smaller in the puzzle example.
+type Memory struct {
+	walls  []point.Point
+	size   int
+	fallen int
 }
 
 type Cell string
 
 type Step struct {
-	position  Vector
+	position  point.Point
 	direction int
 }
 
 type Simulator struct {
-	size          int
-	grid          map[Vector]Cell
-	walls         []Vector
-	path          []Step
-	start         Vector
-	end           Vector
-	score         int
-	exploredPaths [][]Step
+	size     int
+	grid     map[point.Point]Cell
+	walls    []point.Point
+	path     []Step
+	start    point.Point
+	end      point.Point
+	score    int
+	explored []Step
 }
 
 func (s *Simulator) createGrid() *Simulator {
-	s.grid = make(map[Vector]Cell)
+	s.grid = make(map[point.Point]Cell)
 	for r := 0; r < s.size; r++ {
 		for c := 0; c < s.size; c++ {
-			v := Vector{c, r}
+			v := point.Pt(c, r)
 			s.grid[v] = "."
 		}
 	}
 	return s
 }
 
-func (s *Simulator) addWalls(newWalls []Vector) *Simulator {
+func (s *Simulator) addWalls(newWalls []point.Point) *Simulator {
 	s.walls = append(s.walls, newWalls...)
 	return s
 }
 
-func (s *Simulator) addWall(newWall Vector) *Simulator {
+func (s *Simulator) addWall(newWall point.Point) *Simulator {
 	s.walls = append(s.walls, newWall)
 	return s
 }
 
-func (s *Simulator) isWall(v Vector) bool {
+func (s *Simulator) isWall(v point.Point) bool {
 	for _, wall := range s.walls {
 		if wall == v {
 			return true
@@ -81,7 +102,7 @@ func (s *Simulator) isWall(v Vector) bool {
 	return false
 }
 
-func (s *Simulator) isPath(v Vector) bool {
+func (s *Simulator) isPath(v point.Point) bool {
 	for _, path := range s.path {
 		if path.position == v {
 			return true
@@ -91,8 +112,12 @@ func (s *Simulator) isPath(v Vector) bool {
 }
 
 func (s *Simulator) renderGrid() {
-	// clear screen
-	if len(s.exploredPaths) <= 1 {
+	if !aoc.Visual() {
+		return
+	}
+
+	// clear screen on the first frame
+	if len(s.explored) <= len(directions) {
 		fmt.Print("\033[H\033[2J")
 		for i := 0; i < s.size+2; i++ {
 			fmt.Println()
@@ -110,22 +135,17 @@ func (s *Simulator) renderGrid() {
 	for r := range render {
 		render[r] = make([]string, s.size)
 		for c := range render[r] {
-			v := Vector{c, r}
+			v := point.Pt(c, r)
 			if s.isWall(v) {
 				render[r][c] = orange + "#" + reset
 			} else if s.score != -1 && s.score != int(^uint(0)>>1) && s.isPath(v) {
 				render[r][c] = green + "O" + reset
 			} else {
 				foundDirection := false
-				for _, path := range s.exploredPaths {
-					for _, step := range path {
-						if step.position == v {
-							render[r][c] = red + directionSymbols[step.direction] + reset
-							foundDirection = true
-							break
-						}
-					}
-					if foundDirection {
+				for _, step := range s.explored {
+					if step.position == v {
+						render[r][c] = red + directionSymbols[step.direction] + reset
+						foundDirection = true
 						break
 					}
 				}
@@ -146,107 +166,56 @@ func (s *Simulator) renderGrid() {
 	time.Sleep(50 * time.Millisecond)
 }
 
-type QueueItem struct {
-	position  Vector
-	direction int
-	score     int
-	path      []Step
-}
-type Queue []*QueueItem
-
-func (queue *Queue) Pop() interface{} {
-	item := (*queue)[0]
-	*queue = (*queue)[1:]
-	return item
-}
-func (queue *Queue) Push(item interface{}) { *queue = append(*queue, item.(*QueueItem)) }
-func (queue Queue) Swap(a, b int)          { queue[a], queue[b] = queue[b], queue[a] }
-func (queue Queue) Len() int               { return len(queue) }
-
-// sort queue by lowest score
-func (queue Queue) Less(a, b int) bool { return queue[a].score < queue[b].score }
+// down right up left, like directionSymbols
+var directions = []point.Point{point.Down, point.Right, point.Up, point.Left}
 
 func (s *Simulator) solve(render bool) *Simulator {
-	// down right up left
-	directions := []Vector{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-	visited := make(map[string]bool)
-
-	queue := &Queue{}
-	heap.Init(queue)
-
-	heap.Push(queue, &QueueItem{
-		position: s.start,
-		score:    0,
-		path:     []Step{{s.start, 0}},
-	})
-
-	for queue.Len() > 0 {
-		current := heap.Pop(queue).(*QueueItem)
-
-		if current.score > s.score {
-			continue
-		}
-
-		if current.position == s.end {
-			s.score = current.score
-			s.path = current.path
-			return s
-		}
-
-		key := fmt.Sprintf("%d_%d_%d", current.position.x, current.position.y, current.direction)
-		if visited[key] {
-			continue
-		}
-		visited[key] = true
-
+	next := func(current point.Point) []point.Point {
+		var steps []point.Point
 		for dirIndex, dir := range directions {
-			next := Vector{
-				x: current.position.x + dir.x,
-				y: current.position.y + dir.y,
-			}
-			nextKey := fmt.Sprintf("%d_%d_%d", next.x, next.y, dirIndex)
-
-			// out of bounds
-			if next.x < 0 || next.x >= s.size ||
-				next.y < 0 || next.y >= s.size ||
-				s.isWall(next) || visited[nextKey] {
+			n := current.Add(dir)
+			if !n.In(s.size, s.size) || s.isWall(n) {
 				continue
 			}
-
-			newPath := make([]Step, len(current.path))
-			copy(newPath, current.path)
-			newPath = append(newPath, Step{position: next, direction: dirIndex})
-
-			// add to stack
-			heap.Push(queue, &QueueItem{
-				position: next,
-				score:    current.score + 1,
-				path:     newPath,
-			})
+			steps = append(steps, n)
+			if render {
+				s.explored = append(s.explored, Step{position: n, direction: dirIndex})
+			}
 		}
-
-		s.path = current.path
 		if render {
-			s.exploredPaths = append(s.exploredPaths, current.path)
 			s.renderGrid()
 		}
+		return steps
 	}
+	atEnd := func(p point.Point) bool { return p == s.end }
 
-	s.renderGrid()
-	s.score = -1
+	path, ok := search.BFS(s.start, next, atEnd)
+	if !ok {
+		s.renderGrid()
+		s.score = -1
+		return s
+	}
+
+	s.score = path.Cost
+	s.path = []Step{{s.start, 0}}
+	for i := 1; i < len(path.States); i++ {
+		step := path.States[i].Sub(path.States[i-1])
+		s.path = append(s.path, Step{position: path.States[i], direction: slices.Index(directions, step)})
+	}
 	return s
 }
 
-func part1(walls []Vector) int {
-	mapSize := 71
-	numberOfWalls := 1024
+func part1(memory Memory) (aoc.Answer, error) {
+	walls := memory.walls
+	mapSize := memory.size
+	numberOfWalls := memory.fallen
 	renderSteps := false
 
 	simulator := Simulator{
 		size:  mapSize,
-		walls: []Vector{},
-		start: Vector{0, 0},
-		end:   Vector{mapSize - 1, mapSize - 1},
+		walls: []point.Point{},
+		start: point.Point{},
+		end:   point.Pt(mapSize-1, mapSize-1),
 		path:  []Step{},
 		score: int(^uint(0) >> 1),
 	}
@@ -255,34 +224,33 @@ func part1(walls []Vector) int {
 	simulator.solve(renderSteps)
 	simulator.renderGrid()
 
-	fmt.Println("Shortest path:", simulator.score)
-	return simulator.score
+	return aoc.Int(simulator.score), nil
 }
 
-func part2(walls []Vector) string {
-	mapSize := 71
-	numberOfWalls := 1024
+func part2(memory Memory) (aoc.Answer, error) {
+	walls := memory.walls
+	mapSize := memory.size
+	numberOfWalls := memory.fallen
 	renderSteps := false
 
 	simulator := Simulator{
 		size:  mapSize,
-		walls: []Vector{},
-		start: Vector{0, 0},
-		end:   Vector{mapSize - 1, mapSize - 1},
+		walls: []point.Point{},
+		start: point.Point{},
+		end:   point.Pt(mapSize-1, mapSize-1),
 		path:  []Step{},
 		score: int(^uint(0) >> 1),
 	}
 
 	simulator.createGrid().addWalls(walls[:numberOfWalls]).solve(renderSteps)
-	blockingWall := Vector{}
+	blockingWall := point.Point{}
 	for i := numberOfWalls; i < len(walls); i++ {
 		wall := walls[i]
 
 		simulation := Simulator{
 			size:  mapSize,
-			walls: make([]Vector, numberOfWalls),
-			start: Vector{0, 0},
-			end:   Vector{mapSize - 1, mapSize - 1},
+			start: point.Point{},
+			end:   point.Pt(mapSize-1, mapSize-1),
 			path:  []Step{},
 			score: int(^uint(0) >> 1),
 		}
@@ -295,19 +263,5 @@ func part2(walls []Vector) string {
 		}
 	}
 
-	key := fmt.Sprintf("%d,%d", blockingWall.x, blockingWall.y)
-	fmt.Println("Blocking wall:", key)
-	return key
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.String(blockingWall.String()), nil
 }
@@ -1,18 +1,16 @@
-package main
+package day19
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"aoc"
+	"regexp"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 19, aoc.Solution[Inputs]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
 type Pattern string
@@ -21,26 +19,35 @@ type Inputs struct {
 	desiredPatterns   []Pattern
 }
 
-func formatData(rows []string) Inputs {
+// stripes are the colours a towel or design is made of.
+var stripes = regexp.MustCompile(`[wubrg]+`)
+
+func formatData(in aoc.Input) (Inputs, error) {
+	sections, err := in.SectionsN(2)
+	if err != nil {
+		return Inputs{}, err
+	}
+	if len(sections[0]) != 1 {
+		return Inputs{}, sections[0][1].Errorf("expected the towel patterns on one line")
+	}
 	inputs := Inputs{
 		availablePatterns: map[Pattern]bool{},
 		desiredPatterns:   []Pattern{},
 	}
-	for i, row := range rows {
-		if row == "" {
-			continue
+	for _, pattern := range sections[0][0].Split(", ") {
+		if _, err := pattern.Match(stripes); err != nil {
+			return Inputs{}, err
 		}
-		if i == 0 {
-			availablePatterns := strings.Split(row, ", ")
-			for _, pattern := range availablePatterns {
-				inputs.availablePatterns[Pattern(pattern)] = true
-			}
-		} else {
-			inputs.desiredPatterns = append(inputs.desiredPatterns, Pattern(row))
+		inputs.availablePatterns[Pattern(pattern.Text)] = true
+	}
+	for _, row := range sections[1] {
+		if _, err := row.Match(stripes); err != nil {
+			return Inputs{}, err
 		}
+		inputs.desiredPatterns = append(inputs.desiredPatterns, Pattern(row.Text))
 	}
 
-	return inputs
+	return inputs, nil
 }
 
 func (pattern *Pattern) canBeMadeFrom(availablePatterns map[Pattern]bool) bool {
@@ -62,7 +69,7 @@ func (pattern *Pattern) canBeMadeFrom(availablePatterns map[Pattern]bool) bool {
 	return memo[len(*pattern)]
 }
 
-func part1(inputs Inputs) int {
+func part1(inputs Inputs) (aoc.Answer, error) {
 
 	possiblePatternsCount := 0
 
@@ -72,8 +79,7 @@ func part1(inputs Inputs) int {
 		}
 	}
 
-	fmt.Println(possiblePatternsCount)
-	return possiblePatternsCount
+	return aoc.Int(possiblePatternsCount), nil
 }
 
 func (pattern *Pattern) calculatePatternCombinations(availablePatterns map[Pattern]bool) int {
@@ -95,7 +101,7 @@ func (pattern *Pattern) calculatePatternCombinations(availablePatterns map[Patte
 	return memo[len(*pattern)]
 }
 
-func part2(inputs Inputs) int {
+func part2(inputs Inputs) (aoc.Answer, error) {
 
 	sumPossiblePatternCombinations := 0
 
@@ -103,18 +109,5 @@ func part2(inputs Inputs) int {
 		sumPossiblePatternCombinations += desiredPattern.calculatePatternCombinations(inputs.availablePatterns)
 	}
 
-	fmt.Println(sumPossiblePatternCombinations)
-	return sumPossiblePatternCombinations
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sumPossiblePatternCombinations), nil
 }
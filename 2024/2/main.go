@@ -1,48 +1,46 @@
-package main
+package day2
 
 import (
+	"aoc"
 	"fmt"
 	"math"
-	"os"
-	"path/filepath"
-	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 2, aoc.Solution[[][]int]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(reports []string) [][]int {
+func formatData(in aoc.Input) ([][]int, error) {
+	reports := in.Rows()
 	levels := make([][]int, len(reports))
 	for i, report := range reports {
-		nums := strings.Fields(report)
-		level := make([]int, len(nums))
-		for j, num := range nums {
-			var n int
-			fmt.Sscanf(num, "%d", &n)
-			level[j] = n
+		level, err := report.Ints("")
+		if err != nil {
+			return nil, err
+		}
+		if len(level) == 0 {
+			return nil, report.Errorf("expected a report, got an empty line")
 		}
 		levels[i] = level
 	}
-	return levels
+	return levels, nil
 }
 
-func part1(levels [][]int) int {
+func part1(levels [][]int) (aoc.Answer, error) {
 	safeLevelSeen := 0
 	for _, level := range levels {
 		if checkLevel(level) {
 			safeLevelSeen++
 		}
 	}
-	fmt.Printf("safeLevelSeen: %d\n", safeLevelSeen)
-	return safeLevelSeen
+	return aoc.Int(safeLevelSeen), nil
 }
 
-func part2(levels [][]int) int {
+func part2(levels [][]int) (aoc.Answer, error) {
 	memo := make(map[string]bool)
 	safeLevelSeen := 0
 
@@ -66,8 +64,7 @@ func part2(levels [][]int) int {
 			safeLevelSeen++
 		}
 	}
-	fmt.Printf("safeLevelSeen: %d\n", safeLevelSeen)
-	return safeLevelSeen
+	return aoc.Int(safeLevelSeen), nil
 }
 
 func checkLevel(level []int) bool {
@@ -101,7 +98,7 @@ func checkLevel(level []int) bool {
 func retry(level []int, memo map[string]bool) bool {
 	var isSafeLevel bool
 	for j := 0; j < len(level); j++ {
-		newLevel := append(level[:j], level[j+1:]...)
+		newLevel := append(append([]int{}, level[:j]...), level[j+1:]...)
 		newLevelKey := fmt.Sprint(newLevel)
 
 		if val, exists := memo[newLevelKey]; exists {
@@ -120,15 +117,3 @@ func retry(level []int, memo map[string]bool) bool {
 	}
 	return isSafeLevel
 }
-
-func main() {
-	reports, err := readData()
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-
-	formattedData := formatData(reports)
-	part1(formattedData)
-	part2(formattedData)
-}
@@ -1,3 +0,0 @@
-module main.go
-
-go 1.23.3
@@ -1,77 +1,87 @@
-package main
+package day20
 
 import (
+	"aoc"
+	"aoc/point"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 20, aoc.Solution[Maze]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-type Vector struct {
-	x, y int
-}
 type CheatSegment struct {
-	start Vector
-	end   Vector
+	start point.Point
+	end   point.Point
 }
 type Maze struct {
 	grid      [][]string
-	walls     map[Vector]bool
-	start     Vector
-	end       Vector
-	bestPaths []Vector
+	walls     map[point.Point]bool
+	start     point.Point
+	end       point.Point
+	bestPaths []point.Point
 	cheats    map[CheatSegment]int
 }
 
-func formatData(rows []string) Maze {
+func formatData(in aoc.Input) (Maze, error) {
+	rows, err := in.Grid("#.SE")
+	if err != nil {
+		return Maze{}, err
+	}
 	maze := Maze{
 		grid:      make([][]string, len(rows)),
-		walls:     make(map[Vector]bool),
-		bestPaths: []Vector{},
+		walls:     make(map[point.Point]bool),
+		bestPaths: []point.Point{},
 		cheats:    make(map[CheatSegment]int),
 	}
 
+	starts, ends := 0, 0
 	for r, row := range rows {
-		maze.grid[r] = make([]string, len(row))
-		for c, char := range row {
-			v := Vector{c, r}
+		maze.grid[r] = make([]string, len(row.Text))
+		for c, char := range row.Text {
+			v := point.Pt(c, r)
 			switch char {
 			case '#':
 				maze.walls[v] = true
 			case 'S':
 				maze.start = v
+				starts++
 			case 'E':
 				maze.end = v
+				ends++
 			}
 			maze.grid[r][c] = string(char)
 		}
 	}
+	if starts != 1 {
+		return Maze{}, in.Errorf("expected one start, got %d", starts)
+	}
+	if ends != 1 {
+		return Maze{}, in.Errorf("expected one end, got %d", ends)
+	}
 
-	return maze
+	return maze, nil
 }
 
 func (maze *Maze) solve() *Maze {
-	// North, East, South, West
-	directions := []Vector{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	// the track has a single way on, so the order doesn't matter
+	directions := point.Cardinal
 
 	currentTile := maze.start
 	maze.bestPaths = append(maze.bestPaths, currentTile)
-	lastTile := Vector{-1, -1}
+	lastTile := point.Pt(-1, -1) // off the track
 
-	for maze.grid[currentTile.y][currentTile.x] != "E" {
-		tile := Vector{}
+	for maze.grid[currentTile.Y][currentTile.X] != "E" {
+		tile := point.Point{}
 
 		for _, dir := range directions {
-			nextTile := Vector{currentTile.x + dir.x, currentTile.y + dir.y}
+			nextTile := currentTile.Add(dir)
 			if nextTile != lastTile && !maze.walls[nextTile] {
 				tile = nextTile
 				break
@@ -94,7 +104,7 @@ func (maze *Maze) identifyCheats(maxLength int) *Maze {
 			next := maze.bestPaths[nextIndex]
 
 			// calculate distance between current best path & next best path
-			distance := abs(next.x-bestPath.x) + abs(next.y-bestPath.y)
+			distance := next.Manhattan(bestPath)
 
 			// if distance is within maxLength, it's a cheat/shortcut
 			if distance <= maxLength {
@@ -105,20 +115,13 @@ func (maze *Maze) identifyCheats(maxLength int) *Maze {
 	return maze
 }
 
-func abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
-
 func (m *Maze) copy() *Maze {
 	newMaze := Maze{
 		grid:      make([][]string, len(m.grid)),
-		walls:     make(map[Vector]bool),
+		walls:     make(map[point.Point]bool),
 		start:     m.start,
 		end:       m.end,
-		bestPaths: make([]Vector, len(m.bestPaths)),
+		bestPaths: make([]point.Point, len(m.bestPaths)),
 		cheats:    make(map[CheatSegment]int),
 	}
 
@@ -137,6 +140,10 @@ func (m *Maze) copy() *Maze {
 }
 
 func (m *Maze) renderGrid() {
+	if !aoc.Visual() {
+		return
+	}
+
 	// clear screen
 	fmt.Print("\033[H\033[2J")
 
@@ -146,7 +153,7 @@ func (m *Maze) renderGrid() {
 	reset := "\033[0m"
 	fmt.Println()
 
-	bestPathMap := make(map[Vector]bool)
+	bestPathMap := make(map[point.Point]bool)
 	for _, v := range m.bestPaths {
 		bestPathMap[v] = true
 	}
@@ -155,7 +162,7 @@ func (m *Maze) renderGrid() {
 	for r := range render {
 		render[r] = make([]string, len(m.grid[r]))
 		for c := range render[r] {
-			v := Vector{c, r}
+			v := point.Pt(c, r)
 			if m.walls[v] {
 				render[r][c] = orange + "#" + reset
 			} else if bestPathMap[v] {
@@ -182,7 +189,7 @@ func (m *Maze) renderGrid() {
 	time.Sleep(250 * time.Millisecond)
 }
 
-func part1(input Maze) int {
+func part1(input Maze) (aoc.Answer, error) {
 	maze := input.copy()
 	maze.renderGrid()
 	maze.solve()
@@ -199,11 +206,10 @@ func part1(input Maze) int {
 		}
 	}
 
-	fmt.Println("Part 1:", countCheats)
-	return countCheats
+	return aoc.Int(countCheats), nil
 }
 
-func part2(input Maze) int {
+func part2(input Maze) (aoc.Answer, error) {
 	maze := input.copy()
 	// maze.renderGrid()
 	maze.solve()
@@ -220,18 +226,5 @@ func part2(input Maze) int {
 		}
 	}
 
-	fmt.Println("Part 2:", countCheats)
-	return countCheats
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(countCheats), nil
 }
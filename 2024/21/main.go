@@ -1,20 +1,18 @@
-package main
+package day21
 
 import (
+	"aoc"
+	"aoc/point"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
-	"strconv"
-	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 21, aoc.Solution[Codes]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
 type DoorCode struct {
@@ -23,15 +21,36 @@ type DoorCode struct {
 	instructions string
 }
 
-func formatData(rows []string) []DoorCode {
+// Codes are the door codes to type, along with how many robots stand between
+// the door and us in part 2.
+type Codes struct {
+	doorCodes      []DoorCode
+	numberOfRobots int
+}
+
+var doorCode = regexp.MustCompile(`(\d+)A`)
+
+func formatData(in aoc.Input) (Codes, error) {
+	numberOfRobots, err := in.Int("numberOfRobots", 25)
+	if err != nil {
+		return Codes{}, err
+	}
+
+	rows := in.Rows()
 	doorCodes := make([]DoorCode, len(rows))
-	regex := regexp.MustCompile(`\d+`)
 	for r, row := range rows {
-		// extract number from row removing leading zeros
-		num, _ := strconv.Atoi(regex.FindString(row))
-		doorCodes[r] = DoorCode{code: row, num: num, instructions: ""}
+		// the number of a code like "029A", leading zeros and all
+		matches, err := row.Match(doorCode)
+		if err != nil {
+			return Codes{}, err
+		}
+		num, err := matches[0].Int()
+		if err != nil {
+			return Codes{}, err
+		}
+		doorCodes[r] = DoorCode{code: row.Text, num: num, instructions: ""}
 	}
-	return doorCodes
+	return Codes{doorCodes, numberOfRobots}, nil
 }
 
 func (keypad *Keypad) generateInstructions(code string) string {
@@ -47,14 +66,12 @@ func (keypad *Keypad) generateInstructions(code string) string {
 	return instructions
 }
 
-type Position [2]int
-
 type KeypadPathMap map[string]map[string]string
 
 type Keypad struct {
 	keypad     [][]string
 	position   string
-	directions [4]Position
+	directions []point.Point
 	pathFromTo KeypadPathMap
 }
 
@@ -66,13 +83,8 @@ func getNumericKeypad() *Keypad {
 			{"1", "2", "3"},
 			{"", "0", "A"},
 		},
-		position: "A",
-		directions: [4]Position{
-			{-1, 0}, // ^
-			{0, 1},  // >
-			{1, 0},  // v
-			{0, -1}, // <
-		},
+		position:   "A",
+		directions: point.Cardinal, // ^ > v <
 		pathFromTo: KeypadPathMap{
 			"A": {
 				"0": "<A",
@@ -227,13 +239,8 @@ func getDirectionalKeypad() *Keypad {
 			{"", "^", "A"},
 			{"<", "v", ">"},
 		},
-		position: "A",
-		directions: [4]Position{
-			{1, 0},  // v
-			{0, 1},  // >
-			{-1, 0}, // ^
-			{0, -1}, // <
-		},
+		position:   "A",
+		directions: []point.Point{point.Down, point.Right, point.Up, point.Left},
 		pathFromTo: KeypadPathMap{
 			"A": {
 				"^": "<A",
@@ -274,7 +281,8 @@ func getDirectionalKeypad() *Keypad {
 	}
 }
 
-func part1(doorCodes []DoorCode) int {
+func part1(codes Codes) (aoc.Answer, error) {
+	doorCodes := codes.doorCodes
 	sum := 0
 
 	for _, doorCode := range doorCodes {
@@ -282,12 +290,13 @@ func part1(doorCodes []DoorCode) int {
 		robotInstruction := getDirectionalKeypad().generateInstructions(doorInstruction)
 		shortestInstruction := getDirectionalKeypad().generateInstructions(robotInstruction)
 
-		fmt.Println(doorCode.code, ":", len(shortestInstruction), "x", doorCode.num)
+		if aoc.Visual() {
+			fmt.Println(doorCode.code, ":", len(shortestInstruction), "x", doorCode.num)
+		}
 		sum += len(shortestInstruction) * doorCode.num
 	}
 
-	fmt.Println("Part 1:", sum)
-	return sum
+	return aoc.Int(sum), nil
 }
 
 type MemoKey struct {
@@ -328,29 +337,18 @@ func (k *Keypad) countInstructionsLength(instructionsPath string, depth int) int
 	return length
 }
 
-func part2(doorCodes []DoorCode) int {
+func part2(codes Codes) (aoc.Answer, error) {
+	doorCodes, numberOfRobots := codes.doorCodes, codes.numberOfRobots
 	sum := 0
-	numberOfRobots := 25
 
 	for _, doorCode := range doorCodes {
 		doorInstruction := getNumericKeypad().generateInstructions(doorCode.code)
 		instruction := getDirectionalKeypad().countInstructionsLength(doorInstruction, numberOfRobots)
-		fmt.Println(doorCode.code, ":", instruction, "x", doorCode.num)
+		if aoc.Visual() {
+			fmt.Println(doorCode.code, ":", instruction, "x", doorCode.num)
+		}
 		sum += instruction * doorCode.num
 	}
 
-	fmt.Println("Part 2:", sum)
-	return sum
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
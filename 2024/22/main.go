@@ -1,28 +1,28 @@
-package main
+package day22
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
+	"aoc"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 22, aoc.Solution[[]int]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) []int {
+func formatData(in aoc.Input) ([]int, error) {
+	rows := in.Rows()
 	data := make([]int, len(rows))
 	for i, row := range rows {
-		num, _ := strconv.Atoi(row)
+		num, err := row.Int()
+		if err != nil {
+			return nil, err
+		}
 		data[i] = num
 	}
-	return data
+	return data, nil
 }
 
 func simulateSecret(initial int, iterations int) int {
@@ -33,13 +33,13 @@ func simulateSecret(initial int, iterations int) int {
 	return secret
 }
 
-func part1(data []int) {
+func part1(data []int) (aoc.Answer, error) {
 	totalSum := 0
 	for _, initial := range data {
 		finalSecret := simulateSecret(initial, 2000)
 		totalSum += finalSecret
 	}
-	fmt.Println("Total Sum:", totalSum)
+	return aoc.Int(totalSum), nil
 }
 
 func generateSecret(secret int) int {
@@ -103,7 +103,7 @@ func getBuyingWindows(bananas []int) map[Window]int {
 	return sequences
 }
 
-func part2(data []int) int {
+func part2(data []int) (aoc.Answer, error) {
 	memo := make(map[Window]int)
 
 	for _, initial := range data {
@@ -122,18 +122,5 @@ func part2(data []int) int {
 		}
 	}
 
-	fmt.Println("Part 2:", bananas)
-	return bananas
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(bananas), nil
 }
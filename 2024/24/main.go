@@ -1,20 +1,25 @@
-package main
+package day24
 
 import (
+	"aoc"
 	"fmt"
-	"os"
-	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(strings.TrimSpace(string(data)), "\n"), nil
+type Device struct {
+	wireValues map[string]int
+	gates      []Gate
+}
+
+func init() {
+	aoc.Register(2024, 24, aoc.Solution[Device]{
+		Parse: formatData,
+		Part1: func(device Device) (aoc.Answer, error) { return part1(device.wireValues, device.gates) },
+		Part2: func(device Device) (aoc.Answer, error) { return part2(device.gates) },
+	})
 }
 
 type Gate struct {
@@ -24,34 +29,59 @@ type Gate struct {
 	output   string
 }
 
-func formatData(rows []string) (map[string]int, []Gate) {
+var (
+	// an initial wire value, like "x00: 1"
+	wireLine = regexp.MustCompile(`(\w+): ([01])`)
+	// a gate, like "x00 AND y00 -> z00"
+	gateLine = regexp.MustCompile(`(\w+) (AND|OR|XOR) (\w+) -> (\w+)`)
+)
+
+func formatData(in aoc.Input) (Device, error) {
+	rows := in.Rows()
 	wireValuesMap := make(map[string]int)
 	gates := []Gate{}
 
 	for _, row := range rows {
-		if strings.Contains(row, ":") {
-			parts := strings.Split(row, ": ")
-			wire := parts[0]
-			value, _ := strconv.Atoi(parts[1])
-			wireValuesMap[wire] = value
-
-		} else if strings.Contains(row, " -> ") {
-			parts := strings.Split(row, " -> ")
-			output := parts[1]
-			gateParts := strings.Fields(parts[0])
-
-			if len(gateParts) == 3 {
-				gates = append(gates, Gate{
-					input1:   gateParts[0],
-					operator: gateParts[1],
-					input2:   gateParts[2],
-					output:   output,
-				})
+		switch {
+		case row.Text == "":
+			continue
+		case strings.Contains(row.Text, ": "):
+			matches, err := row.Match(wireLine)
+			if err != nil {
+				return Device{}, err
+			}
+			value, err := matches[1].Int()
+			if err != nil {
+				return Device{}, err
+			}
+			wireValuesMap[matches[0].Text] = value
+		default:
+			matches, err := row.Match(gateLine)
+			if err != nil {
+				return Device{}, err
+			}
+			gates = append(gates, Gate{
+				input1:   matches[0].Text,
+				operator: matches[1].Text,
+				input2:   matches[2].Text,
+				output:   matches[3].Text,
+			})
+		}
+	}
+
+	outputs := make(map[string]bool, len(gates))
+	for _, gate := range gates {
+		outputs[gate.output] = true
+	}
+	for _, gate := range gates {
+		for _, wire := range []string{gate.input1, gate.input2} {
+			if _, set := wireValuesMap[wire]; !set && !outputs[wire] {
+				return Device{}, in.Errorf("wire %s is read by a gate but never set", wire)
 			}
 		}
 	}
 
-	return wireValuesMap, gates
+	return Device{wireValuesMap, gates}, nil
 }
 
 func (g Gate) evaluate(in1, in2 int) int {
@@ -67,7 +97,7 @@ func (g Gate) evaluate(in1, in2 int) int {
 	}
 }
 
-func part1(wireValues map[string]int, inputs []Gate) {
+func part1(wireValues map[string]int, inputs []Gate) (aoc.Answer, error) {
 	gates := inputs
 
 	for len(gates) > 0 {
@@ -84,6 +114,10 @@ func part1(wireValues map[string]int, inputs []Gate) {
 				newGates = append(newGates, gate)
 			}
 		}
+		// a pass that settles nothing never will, the gates wait on each other
+		if len(newGates) == len(gates) {
+			return aoc.Answer{}, fmt.Errorf("%d gates wait on each other in a loop", len(gates))
+		}
 		gates = newGates
 	}
 
@@ -98,8 +132,11 @@ func part1(wireValues map[string]int, inputs []Gate) {
 		resultBinary = strconv.Itoa(value) + resultBinary
 	}
 
-	resultDecimal, _ := strconv.ParseInt(resultBinary, 2, 64)
-	fmt.Println("Part 1:", resultDecimal)
+	resultDecimal, err := strconv.ParseInt(resultBinary, 2, 64)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(resultDecimal), nil
 }
 
 func findSwappedGates(gates []Gate) []string {
@@ -147,21 +184,9 @@ func isXOrY(wire string) bool {
 	return (wire[0] == 'x' || wire[0] == 'y') && temp != 0
 }
 
-func part2(gates []Gate) {
+func part2(gates []Gate) (aoc.Answer, error) {
 	swappedGates := findSwappedGates(gates)
 	sort.Strings(swappedGates)
 
-	fmt.Println("Part 2:", strings.Join(swappedGates, ","))
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	wireValues, gates := formatData(data)
-	part1(wireValues, gates)
-	part2(gates)
+	return aoc.String(strings.Join(swappedGates, ",")), nil
 }
@@ -1,18 +1,14 @@
-package main
+package day25
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"aoc"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 25, aoc.Solution[Inputs]{
+		Parse: formatData,
+		Part1: part1,
+	})
 }
 
 type Lock struct {
@@ -26,20 +22,26 @@ type Inputs struct {
 	keys  []Key
 }
 
-func formatData(lines []string) Inputs {
+func formatData(in aoc.Input) (Inputs, error) {
 	var result Inputs
 
-	// 7 lines per lock/key + 1 empty line
-	for i := 0; i < len(lines); i += 8 {
-		if i+7 > len(lines) {
-			break
+	// 7 lines per lock/key, separated by blank lines
+	for _, lines := range in.Sections() {
+		if len(lines) != 7 {
+			return Inputs{}, lines[0].Errorf("expected a lock or key of 7 rows, got %d", len(lines))
+		}
+		if _, err := aoc.GridOf(in, lines, "#."); err != nil {
+			return Inputs{}, err
+		}
+		if width := len(lines[0].Text); width != 5 {
+			return Inputs{}, lines[0].Errorf("expected 5 columns, got %d", width)
 		}
 
 		current := [5]int{}
 		isLock := false
 
 		for row := 0; row < 7; row++ {
-			for c, char := range lines[i+row] {
+			for c, char := range lines[row].Text {
 				if char == '#' {
 					current[c]++
 				}
@@ -60,10 +62,10 @@ func formatData(lines []string) Inputs {
 			result.keys = append(result.keys, Key{heights: current})
 		}
 	}
-	return result
+	return result, nil
 }
 
-func part1(inputs Inputs) {
+func part1(inputs Inputs) (aoc.Answer, error) {
 	count := 0
 
 	for _, lock := range inputs.locks {
@@ -83,19 +85,7 @@ func part1(inputs Inputs) {
 		}
 	}
 
-	fmt.Println("Part 1:", count)
+	return aoc.Int(count), nil
 }
 
 // func part2() {}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	// part2(formattedData)
-}
@@ -1,27 +1,25 @@
-package main
+package day3
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
+	"aoc"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 3, aoc.Solution[[]string]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) []string {
-	return rows
+func formatData(in aoc.Input) ([]string, error) {
+	return in.Lines(), nil
 }
 
-func part1(data []string) int {
+func part1(data []string) (aoc.Answer, error) {
 	multiplierRegex := regexp.MustCompile(`mul\(\d{1,3},\d{1,3}\)`)
 	mulRegex := regexp.MustCompile(`(\d)+`)
 
@@ -38,11 +36,10 @@ func part1(data []string) int {
 		}
 
 	}
-	fmt.Println("sum", sum)
-	return sum
+	return aoc.Int(sum), nil
 }
 
-func part2(data []string) int {
+func part2(data []string) (aoc.Answer, error) {
 	multiplierRegex := regexp.MustCompile(`(do(n't)?\(\))|(mul\(\d{1,3},\d{1,3}\))`)
 	mulRegex := regexp.MustCompile(`\d+`)
 	instructionRegex := regexp.MustCompile(`do(n't)?\(\)`)
@@ -71,18 +68,5 @@ func part2(data []string) int {
 			}
 		}
 	}
-	fmt.Println("sum", sum)
-	return sum
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
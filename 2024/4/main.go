@@ -1,125 +1,86 @@
-package main
+package day4
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"path/filepath"
+	"aoc"
+	"aoc/grid"
+	"aoc/point"
 )
 
-func readData() ([]string, error) {
-	file, err := os.Open(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var data []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		data = append(data, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return data, nil
+func init() {
+	aoc.Register(2024, 4, aoc.Solution[*grid.Grid[rune]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) [][]rune {
-	var grid [][]rune
-	for _, row := range rows {
-		grid = append(grid, []rune(row))
+func formatData(in aoc.Input) (*grid.Grid[rune], error) {
+	rows, err := in.Grid("XMAS")
+	if err != nil {
+		return nil, err
 	}
-	return grid
+	return grid.Runes(rows)
 }
 
-func part1(grid [][]rune) int {
+func part1(letters *grid.Grid[rune]) (aoc.Answer, error) {
 	count := 0
-	directions := [][]int{
-		{-1, -1}, {-1, 0}, {-1, 1},
-		{0, 1}, {0, -1},
-		{1, -1}, {1, 0}, {1, 1},
-	}
 
-	for row := 0; row < len(grid); row++ {
-		for col := 0; col < len(grid[0]); col++ {
-			for _, direction := range directions {
-				if dfs(grid, row, col, direction, "XMAS") {
-					count++
-				}
+	for cell := range letters.Cells {
+		for _, direction := range point.All {
+			if dfs(letters, cell, direction, "XMAS") {
+				count++
 			}
 		}
 	}
 
-	fmt.Println(count)
-	return count
+	return aoc.Int(count), nil
 }
 
-func dfs(grid [][]rune, row, col int, direction []int, word string) bool {
-	r, c := row, col
-	for _, letter := range word {
-		if r < 0 || r >= len(grid) || c < 0 || c >= len(grid[0]) {
-			return false
+// dfs tells whether word is spelled from cell on in direction.
+func dfs(letters *grid.Grid[rune], cell int, direction point.Point, word string) bool {
+	for i, letter := range word {
+		if i > 0 {
+			var ok bool
+			if cell, ok = letters.Step(cell, direction); !ok {
+				return false
+			}
 		}
-		if grid[r][c] != letter {
+		if letters.At(cell) != letter {
 			return false
 		}
-		r += direction[0]
-		c += direction[1]
 	}
 	return true
 }
 
-func part2(grid [][]rune) int {
+func part2(letters *grid.Grid[rune]) (aoc.Answer, error) {
 	count := 0
-	directions := [][]int{
-		{1, 1},  // right down
-		{1, -1}, // left down
-	}
+	rightDown, leftDown := point.Pt(1, 1), point.Pt(-1, 1)
 
-	for row := 0; row < len(grid); row++ {
-		for col := 0; col < len(grid[0]); col++ {
-			if grid[row][col] != 'M' && grid[row][col] != 'S' {
-				continue
-			}
-
-			word := "MAS"
-			if grid[row][col] == 'S' {
-				word = "SAM"
-			}
-			middle := len(word) / 2
-
-			if col+len(word)-1 >= len(grid[0]) {
-				continue
-			}
+	for cell, letter := range letters.Cells {
+		if letter != 'M' && letter != 'S' {
+			continue
+		}
 
-			crossingWord := "MAS"
-			if grid[row][col+len(word)-1] == 'S' {
-				crossingWord = "SAM"
-			}
+		word := "MAS"
+		if letter == 'S' {
+			word = "SAM"
+		}
 
-			if dfs(grid, row, col, directions[0], word) &&
-				dfs(grid, row, col+len(word)-1, directions[1], crossingWord) &&
-				grid[row+middle][col+middle] == rune(word[middle]) {
-				count++
-			}
+		row, col := letters.Pos(cell)
+		if col+len(word)-1 >= letters.Width {
+			continue
 		}
-	}
+		crossing := letters.Index(row, col+len(word)-1)
 
-	fmt.Println(count)
-	return count
-}
+		crossingWord := "MAS"
+		if letters.At(crossing) == 'S' {
+			crossingWord = "SAM"
+		}
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+		if dfs(letters, cell, rightDown, word) && dfs(letters, crossing, leftDown, crossingWord) {
+			count++
+		}
 	}
 
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(count), nil
 }
@@ -1,59 +1,70 @@
-package main
+package day5
 
 import (
+	"aoc"
+	"aoc/graph"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
+	"slices"
 )
 
 type FormattedData struct {
-	rules   []string
-	updates []string
+	rules   []rule
+	updates [][]int
 }
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+// rule is a page that has to come before another, like "47|53"
+type rule struct {
+	before, after int
+}
+
+func init() {
+	aoc.Register(2024, 5, aoc.Solution[FormattedData]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) FormattedData {
-	var rules, updates []string
-	isEndOfRules := false
+func formatData(in aoc.Input) (FormattedData, error) {
+	sections, err := in.SectionsN(2)
+	if err != nil {
+		return FormattedData{}, err
+	}
+	var data FormattedData
 
-	for _, row := range rows {
-		if row == "" {
-			isEndOfRules = true
-			continue
+	for _, row := range sections[0] {
+		pages, err := row.Ints("|")
+		if err != nil {
+			return FormattedData{}, err
 		}
-		if !isEndOfRules {
-			rules = append(rules, row)
-		} else {
-			updates = append(updates, row)
+		if len(pages) != 2 {
+			return FormattedData{}, row.Errorf("expected a rule like 47|53, got %q", row.Text)
 		}
+		data.rules = append(data.rules, rule{pages[0], pages[1]})
 	}
-	return FormattedData{rules: rules, updates: updates}
+	for _, row := range sections[1] {
+		pages, err := row.Ints(",")
+		if err != nil {
+			return FormattedData{}, err
+		}
+		data.updates = append(data.updates, pages)
+	}
+	return data, nil
 }
 
-func part1(data FormattedData) int {
-	rulesSet := make(map[string]bool)
-	for _, rule := range data.rules {
-		rulesSet[rule] = true
+func part1(data FormattedData) (aoc.Answer, error) {
+	rulesSet := make(map[rule]bool)
+	for _, r := range data.rules {
+		rulesSet[r] = true
 	}
 
-	var validUpdates [][]string
+	var validUpdates [][]int
 
-	for _, update := range data.updates {
-		pages := strings.Split(update, ",")
+	for _, pages := range data.updates {
 		isValidUpdate := true
 
 		for i := 0; i < len(pages)-1; i++ {
-			failingRule := pages[i+1] + "|" + pages[i]
-			if rulesSet[failingRule] {
+			if rulesSet[rule{before: pages[i+1], after: pages[i]}] {
 				isValidUpdate = false
 				break
 			}
@@ -65,82 +76,35 @@ func part1(data FormattedData) int {
 
 	sum := 0
 	for _, validUpdate := range validUpdates {
-		mid, _ := strconv.Atoi(validUpdate[len(validUpdate)/2])
-		sum += mid
+		sum += validUpdate[len(validUpdate)/2]
 	}
 
-	fmt.Println(sum)
-	return sum
+	return aoc.Int(sum), nil
 }
 
-func part2(data FormattedData) int {
+func part2(data FormattedData) (aoc.Answer, error) {
 	var correctedUpdates [][]int
 
-	for _, update := range data.updates {
-		pages := make([]int, 0)
-		for _, p := range strings.Split(update, ",") {
-			num, _ := strconv.Atoi(p)
-			pages = append(pages, num)
+	for _, pages := range data.updates {
+		// only the rules between pages of this update order it
+		inUpdate := make(map[int]bool)
+		order := graph.Directed[int]()
+		for _, page := range pages {
+			inUpdate[page] = true
+			order.AddNode(page)
 		}
-
-		rulesSet := make([][2]int, 0)
-		for _, rule := range data.rules {
-			parts := strings.Split(rule, "|")
-			a, _ := strconv.Atoi(parts[0])
-			b, _ := strconv.Atoi(parts[1])
-
-			containsA := false
-			containsB := false
-			for _, p := range pages {
-				containsA = containsA || p == a
-				containsB = containsB || p == b
-			}
-			if containsA && containsB {
-				rulesSet = append(rulesSet, [2]int{a, b})
+		for _, r := range data.rules {
+			if inUpdate[r.before] && inUpdate[r.after] {
+				order.AddEdge(r.before, r.after)
 			}
 		}
 
-		dependencies := make(map[int]int)
-		for _, rule := range rulesSet {
-			dependencies[rule[1]]++
+		corrected, err := order.TopoSort()
+		if err != nil {
+			return aoc.Answer{}, fmt.Errorf("update %v: %w", pages, err)
 		}
 
-		corrected := make([]int, 0)
-		for len(corrected) < len(pages) {
-			for _, page := range pages {
-
-				containsPage := false
-				for _, c := range corrected {
-					containsPage = containsPage || c == page
-					if containsPage {
-						break
-					}
-				}
-				if containsPage {
-					continue
-				}
-
-				if dependencies[page] < 1 {
-					corrected = append(corrected, page)
-					for _, rule := range rulesSet {
-						if rule[0] == page {
-							dependencies[rule[1]]--
-						}
-					}
-				}
-			}
-		}
-
-		original := strings.Trim(strings.Join(strings.Split(update, ","), ","), " ")
-		correctedStr := ""
-		for i, num := range corrected {
-			if i > 0 {
-				correctedStr += ","
-			}
-			correctedStr += strconv.Itoa(num)
-		}
-
-		if original != correctedStr {
+		if !slices.Equal(pages, corrected) {
 			correctedUpdates = append(correctedUpdates, corrected)
 		}
 	}
@@ -150,18 +114,5 @@ func part2(data FormattedData) int {
 		sum += correctedUpdate[len(correctedUpdate)/2]
 	}
 
-	fmt.Println(sum)
-	return sum
-}
-
-func main() {
-	reports, err := readData()
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-
-	formattedData := formatData(reports)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
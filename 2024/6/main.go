@@ -1,196 +1,129 @@
-package main
+package day6
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strings"
+	"aoc"
+	"aoc/grid"
+	"aoc/point"
 )
 
-type Position [2]int
-type Direction string
-
-var positionsMapping = map[Direction][2]int{
-	"^": {-1, 0},
-	"<": {0, -1},
-	">": {0, 1},
-	"v": {1, 0},
+func init() {
+	aoc.Register(2024, 6, aoc.Solution[*grid.Grid[rune]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-var moveMapping = map[Direction]Direction{
-	"^": ">",
-	"<": "^",
-	">": "v",
-	"v": "<",
+// guardDirections are indices into point.Cardinal, where the next one is a
+// right turn.
+var guardDirections = map[rune]int{
+	'^': 0,
+	'>': 1,
+	'v': 2,
+	'<': 3,
 }
 
 type GameState struct {
-	obstacles      map[string]bool
-	areaLimits     map[string]bool
-	guardPosition  Position
-	guardDirection Direction
+	area           *grid.Grid[rune]
+	guardPosition  int
+	guardDirection int
 }
 
-func initialise(grid [][]string) GameState {
-	obstacles := make(map[string]bool)
-	areaLimits := make(map[string]bool)
-	var guardPosition Position
-	var guardDirection Direction
-
-	for i := 0; i < len(grid); i++ {
-		for j := 0; j < len(grid[i]); j++ {
-			cell := grid[i][j]
-			key := fmt.Sprintf("%d_%d", i, j)
-
-			if cell == "#" {
-				obstacles[key] = true
-			} else if i == len(grid)-1 || j == len(grid[0])-1 || i == 0 || j == 0 {
-				areaLimits[key] = true
-			}
-
-			if cell == "^" || cell == "<" || cell == ">" || cell == "v" {
-				guardPosition = Position{i, j}
-				guardDirection = Direction(cell)
-			}
-		}
-	}
-
+func initialise(area *grid.Grid[rune]) GameState {
+	guard, _ := area.Find(func(cell rune) bool {
+		_, ok := guardDirections[cell]
+		return ok
+	})
 	return GameState{
-		obstacles:      obstacles,
-		areaLimits:     areaLimits,
-		guardPosition:  guardPosition,
-		guardDirection: guardDirection,
+		area:           area,
+		guardPosition:  guard,
+		guardDirection: guardDirections[area.At(guard)],
 	}
 }
 
-func simulate(start Position, direction Direction, newObstacle string, areaLimits, obstacles map[string]bool) bool {
-	localMoves := make(map[string]map[Direction]bool)
-	pos := start
-	dir := direction
+// patrol lists the cells the guard walks through before leaving the area.
+func patrol(state GameState) []int {
+	visited := make([]bool, len(state.area.Cells))
+	var route []int
+	pos, dir := state.guardPosition, state.guardDirection
 
 	for {
-		key := fmt.Sprintf("%d_%d", pos[0], pos[1])
-		if localMoves[key] != nil {
-			if localMoves[key][dir] {
-				return true
-			}
-		} else {
-			localMoves[key] = make(map[Direction]bool)
+		if !visited[pos] {
+			visited[pos] = true
+			route = append(route, pos)
 		}
-		localMoves[key][dir] = true
-
-		nextPos := Position{
-			pos[0] + positionsMapping[dir][0],
-			pos[1] + positionsMapping[dir][1],
+		next, ok := state.area.Step(pos, point.Cardinal[dir])
+		if !ok {
+			return route
 		}
-		nextKey := fmt.Sprintf("%d_%d", nextPos[0], nextPos[1])
-
-		if areaLimits[nextKey] && nextKey != newObstacle {
-			return false
-		}
-
-		if obstacles[nextKey] || nextKey == newObstacle {
-			dir = moveMapping[dir]
-		} else {
-			pos = nextPos
+		if state.area.At(next) == '#' {
+			dir = (dir + 1) % 4
+			continue
 		}
+		pos = next
 	}
 }
 
-func part1(grid [][]string) int {
-	state := initialise(grid)
-	visited := make(map[string]bool)
+// simulate tells whether the guard ends up walking in circles once
+// newObstacle is put in the way.
+func simulate(state GameState, newObstacle int) bool {
+	// a bit per direction the guard walked through each cell in
+	localMoves := make([]uint8, len(state.area.Cells))
+	pos, dir := state.guardPosition, state.guardDirection
 
 	for {
-		key := fmt.Sprintf("%d_%d", state.guardPosition[0], state.guardPosition[1])
-		if state.areaLimits[key] {
-			visited[key] = true
-			break
+		if localMoves[pos]&(1<<dir) != 0 {
+			return true
 		}
+		localMoves[pos] |= 1 << dir
 
-		visited[key] = true
-		nextPosition := Position{
-			state.guardPosition[0] + positionsMapping[state.guardDirection][0],
-			state.guardPosition[1] + positionsMapping[state.guardDirection][1],
-		}
-
-		nextKey := fmt.Sprintf("%d_%d", nextPosition[0], nextPosition[1])
-		if state.obstacles[nextKey] {
-			state.guardDirection = moveMapping[state.guardDirection]
+		next, ok := state.area.Step(pos, point.Cardinal[dir])
+		if !ok {
+			return false
 		}
-
-		state.guardPosition = Position{
-			state.guardPosition[0] + positionsMapping[state.guardDirection][0],
-			state.guardPosition[1] + positionsMapping[state.guardDirection][1],
+		if state.area.At(next) == '#' || next == newObstacle {
+			dir = (dir + 1) % 4
+		} else {
+			pos = next
 		}
 	}
-
-	return len(visited)
 }
 
-func part2(grid [][]string) int {
-	state := initialise(grid)
-	startingPosition := state.guardPosition
-	startingDirection := state.guardDirection
-	visited := make(map[string]bool)
-
-	for {
-		key := fmt.Sprintf("%d_%d", state.guardPosition[0], state.guardPosition[1])
-		if state.areaLimits[key] {
-			visited[key] = true
-			break
-		}
-
-		visited[key] = true
-		nextPosition := Position{
-			state.guardPosition[0] + positionsMapping[state.guardDirection][0],
-			state.guardPosition[1] + positionsMapping[state.guardDirection][1],
-		}
+func part1(area *grid.Grid[rune]) (aoc.Answer, error) {
+	return aoc.Int(len(patrol(initialise(area)))), nil
+}
 
-		nextKey := fmt.Sprintf("%d_%d", nextPosition[0], nextPosition[1])
-		if state.obstacles[nextKey] {
-			state.guardDirection = moveMapping[state.guardDirection]
-		}
+func part2(area *grid.Grid[rune]) (aoc.Answer, error) {
+	state := initialise(area)
+	newObstructions := 0
 
-		state.guardPosition = Position{
-			state.guardPosition[0] + positionsMapping[state.guardDirection][0],
-			state.guardPosition[1] + positionsMapping[state.guardDirection][1],
-		}
-	}
-
-	newObstructions := make(map[string]bool)
-	for cell := range visited {
-		if cell == fmt.Sprintf("%d_%d", startingPosition[0], startingPosition[1]) {
+	for _, cell := range patrol(state) {
+		if cell == state.guardPosition {
 			continue
 		}
-
-		if simulate(startingPosition, startingDirection, cell, state.areaLimits, state.obstacles) {
-			newObstructions[cell] = true
+		if simulate(state, cell) {
+			newObstructions++
 		}
 	}
 
-	return len(newObstructions)
+	return aoc.Int(newObstructions), nil
 }
 
-func readData(filename string) [][]string {
-	file, err := os.Open(filename)
+func formatData(in aoc.Input) (*grid.Grid[rune], error) {
+	rows, err := in.Grid(".#^<>v")
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	defer file.Close()
-
-	var grid [][]string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		grid = append(grid, strings.Split(line, ""))
+	area, err := grid.Runes(rows)
+	if err != nil {
+		return nil, err
 	}
-
-	return grid
-}
-
-func main() {
-	grid := readData("data.txt")
-	fmt.Printf("Part 1: %d\n", part1(grid))
-	fmt.Printf("Part 2: %d\n", part2(grid))
+	guards := area.FindAll(func(cell rune) bool {
+		_, ok := guardDirections[cell]
+		return ok
+	})
+	if len(guards) != 1 {
+		return nil, in.Errorf("expected one guard, got %d", len(guards))
+	}
+	return area, nil
 }
@@ -1,20 +1,16 @@
-package main
+package day7
 
 import (
-	"fmt"
+	"aoc"
 	"math"
-	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 7, aoc.Solution[[][][]int]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
 func concatInts(a, b int) int {
@@ -22,29 +18,30 @@ func concatInts(a, b int) int {
 	return a*multiplier + b
 }
 
-func parseNum(s string) int {
-	num, _ := strconv.Atoi(s)
-	return num
-}
-
-func formatData(rows []string) [][][]int {
+func formatData(in aoc.Input) ([][][]int, error) {
+	rows := in.Rows()
 	equations := make([][][]int, len(rows))
 
 	for i, row := range rows {
-		parts := strings.Split(row, ": ")
-		numbers := strings.Split(parts[1], " ")
+		targetPart, numbersPart, err := row.Cut(": ")
+		if err != nil {
+			return nil, err
+		}
 
-		target := []int{parseNum(parts[0])}
+		target, err := targetPart.Int()
+		if err != nil {
+			return nil, err
+		}
 
-		rest := make([]int, len(numbers))
-		for j, num := range numbers {
-			rest[j] = parseNum(num)
+		rest, err := numbersPart.Ints(" ")
+		if err != nil {
+			return nil, err
 		}
 
-		equations[i] = [][]int{target, rest}
+		equations[i] = [][]int{{target}, rest}
 	}
 
-	return equations
+	return equations, nil
 }
 
 func calculate(eq [][]int, operators []func(int, int) int, resultChan ...chan int) int {
@@ -102,7 +99,7 @@ func evaluateParallel(equations [][][]int, operators []func(int, int) int) int {
 	return sum
 }
 
-func part1(equations [][][]int) int {
+func part1(equations [][][]int) (aoc.Answer, error) {
 	operators := []func(int, int) int{
 		func(a, b int) int { return a + b },
 		func(a, b int) int { return a * b },
@@ -110,11 +107,10 @@ func part1(equations [][][]int) int {
 
 	sum := evaluateParallel(equations, operators)
 
-	fmt.Printf("Sum: %d\n", sum)
-	return sum
+	return aoc.Int(sum), nil
 }
 
-func part2(equations [][][]int) int {
+func part2(equations [][][]int) (aoc.Answer, error) {
 	operators := []func(int, int) int{
 		func(a, b int) int { return a + b },
 		func(a, b int) int { return a * b },
@@ -123,18 +119,5 @@ func part2(equations [][][]int) int {
 
 	sum := evaluateParallel(equations, operators)
 
-	fmt.Printf("Sum: %d\n", sum)
-	return sum
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
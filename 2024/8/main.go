@@ -1,179 +1,100 @@
-package main
+package day8
 
 import (
+	"aoc"
+	"aoc/grid"
 	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
 	"strings"
+	"unicode"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
+func init() {
+	aoc.Register(2024, 8, aoc.Solution[*grid.Grid[rune]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func formatData(in aoc.Input) (*grid.Grid[rune], error) {
+	rows, err := in.Grid("")
 	if err != nil {
 		return nil, err
 	}
-	return strings.Split(string(data), "\n"), nil
+	return grid.Runes(rows)
 }
 
-func formatData(rows []string) ([][]string, error) {
-	grid := make([][]string, len(rows))
-
-	for i, row := range rows {
-		grid[i] = strings.Split(row, "")
+func visualiseGrid(city *grid.Grid[rune], antiNodes AntiNodes) {
+	if !aoc.Visual() {
+		return
 	}
-
-	return grid, nil
+	fmt.Print(city.Render(func(i int, cell rune) string {
+		if antiNodes[i] {
+			return "◆"
+		}
+		return string(cell)
+	}))
+	fmt.Println(strings.Repeat("-", city.Width))
 }
 
-func visualiseGrid(grid [][]string, antiNodes map[string]bool) {
-	var sb strings.Builder
+// AntennasMap lists the cells of the antennas of each frequency.
+type AntennasMap map[rune][]int
+type AntiNodes map[int]bool
 
-	for i, row := range grid {
-		for j, cell := range row {
-			key := fmt.Sprintf("%d_%d", j, i)
-			if antiNodes[key] {
-				sb.WriteString("◆")
-			} else {
-				sb.WriteString(cell)
-			}
-		}
-		if i < len(grid)-1 {
-			sb.WriteString("\n")
+func findAntennas(city *grid.Grid[rune]) AntennasMap {
+	antennasMap := make(AntennasMap)
+	for i, cell := range city.Cells {
+		if unicode.IsLetter(cell) || unicode.IsDigit(cell) {
+			antennasMap[cell] = append(antennasMap[cell], i)
 		}
 	}
-
-	fmt.Println(sb.String())
-
-	if len(grid) > 0 {
-		fmt.Println(strings.Repeat("-", len(grid[0])))
-	}
+	return antennasMap
 }
 
-func isWithinBounds(x, y int, grid [][]string) bool {
-	return x >= 0 && y >= 0 && x < len(grid[0]) && y < len(grid)
-}
-
-type Location struct{ x, y int }
-type AntennasMap map[string][]Location
-type AntiNodes map[string]bool
-
-func part1(grid [][]string) int {
-	visualiseGrid(grid, make(map[string]bool))
-
-	antennasMap := make(AntennasMap)
-	antiNodes := make(AntiNodes)
-
-	for i := 0; i < len(grid); i++ {
-		for j := 0; j < len(grid[i]); j++ {
-			cell := grid[i][j]
-
-			if match, _ := regexp.MatchString(`[a-zA-Z0-9]`, cell); !match {
-				continue
-			}
-
-			if _, exists := antennasMap[cell]; !exists {
-				antennasMap[cell] = []Location{}
-			}
-			antennasMap[cell] = append(antennasMap[cell], Location{j, i})
-
-			for _, locations := range antennasMap {
-				for i := 0; i < len(locations); i++ {
-					for j := i + 1; j < len(locations); j++ {
-						loc1 := locations[i]
-						loc2 := locations[j]
-
-						dx := loc1.x - loc2.x
-						dy := loc1.y - loc2.y
-
-						antiNode1 := []int{loc1.x + dx, loc1.y + dy}
-						antiNode2 := []int{loc2.x - dx, loc2.y - dy}
-
-						if isWithinBounds(antiNode1[0], antiNode1[1], grid) {
-							antiNodes[fmt.Sprintf("%d_%d", antiNode1[0], antiNode1[1])] = true
-						}
-
-						if isWithinBounds(antiNode2[0], antiNode2[1], grid) {
-							antiNodes[fmt.Sprintf("%d_%d", antiNode2[0], antiNode2[1])] = true
-						}
-					}
-				}
-			}
+// antiNodesOf marks the cells k times the antennas' distance away from either
+// of them, for k from first to last while they are on the map.
+func antiNodesOf(city *grid.Grid[rune], antiNodes AntiNodes, antenna1, antenna2, first, last int) {
+	r1, c1 := city.Pos(antenna1)
+	r2, c2 := city.Pos(antenna2)
+	dr, dc := r1-r2, c1-c2
+
+	for k := first; k <= last; k++ {
+		found := false
+		if city.In(r1+dr*k, c1+dc*k) {
+			antiNodes[city.Index(r1+dr*k, c1+dc*k)] = true
+			found = true
+		}
+		if city.In(r2-dr*k, c2-dc*k) {
+			antiNodes[city.Index(r2-dr*k, c2-dc*k)] = true
+			found = true
+		}
+		if !found {
+			return
 		}
 	}
-
-	visualiseGrid(grid, antiNodes)
-	fmt.Println(len(antiNodes))
-	return len(antiNodes)
 }
 
-func part2(grid [][]string) int {
-	visualiseGrid(grid, nil)
-
-	antennasMap := make(AntennasMap)
+func countAntiNodes(city *grid.Grid[rune], first, last int) int {
+	visualiseGrid(city, nil)
 	antiNodes := make(AntiNodes)
 
-	for i := 0; i < len(grid); i++ {
-		for j := 0; j < len(grid[i]); j++ {
-			cell := grid[i][j]
-
-			if match, _ := regexp.MatchString(`[a-zA-Z0-9]`, cell); !match {
-				continue
-			}
-
-			if _, exists := antennasMap[cell]; !exists {
-				antennasMap[cell] = []Location{}
-			}
-			antennasMap[cell] = append(antennasMap[cell], Location{j, i})
-
-			for _, locations := range antennasMap {
-				for i := 0; i < len(locations); i++ {
-					for j := i + 1; j < len(locations); j++ {
-						loc1 := locations[i]
-						loc2 := locations[j]
-
-						dx := loc1.x - loc2.x
-						dy := loc1.y - loc2.y
-
-						k := 0
-						for {
-							localAntiNodes := make(AntiNodes)
-							antiNode1 := []int{loc1.x + dx*k, loc1.y + dy*k}
-							antiNode2 := []int{loc2.x - dx*k, loc2.y - dy*k}
-
-							if isWithinBounds(antiNode1[0], antiNode1[1], grid) {
-								localAntiNodes[fmt.Sprintf("%d_%d", antiNode1[0], antiNode1[1])] = true
-							}
-							if isWithinBounds(antiNode2[0], antiNode2[1], grid) {
-								localAntiNodes[fmt.Sprintf("%d_%d", antiNode2[0], antiNode2[1])] = true
-							}
-							if len(localAntiNodes) == 0 {
-								break
-							}
-							for node := range localAntiNodes {
-								antiNodes[node] = true
-							}
-							k++
-						}
-					}
-				}
+	for _, locations := range findAntennas(city) {
+		for i := 0; i < len(locations); i++ {
+			for j := i + 1; j < len(locations); j++ {
+				antiNodesOf(city, antiNodes, locations[i], locations[j], first, last)
 			}
 		}
 	}
 
-	visualiseGrid(grid, antiNodes)
-	fmt.Println(len(antiNodes))
+	visualiseGrid(city, antiNodes)
 	return len(antiNodes)
 }
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
+func part1(city *grid.Grid[rune]) (aoc.Answer, error) {
+	return aoc.Int(countAntiNodes(city, 1, 1)), nil
+}
 
-	formattedData, err := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+func part2(city *grid.Grid[rune]) (aoc.Answer, error) {
+	// every multiple of the distance, the antennas themselves included
+	return aoc.Int(countAntiNodes(city, 0, max(city.Width, city.Height))), nil
 }
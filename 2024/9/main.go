@@ -1,26 +1,34 @@
-package main
+package day9
 
 import (
+	"aoc"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strconv"
-	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2024, 9, aoc.Solution[string]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) string {
-	return rows[0]
+func formatData(in aoc.Input) (string, error) {
+	rows := in.Rows()
+	if len(rows) != 1 {
+		return "", rows[0].Errorf("expected the disk map on a single line")
+	}
+	if rows[0].Text == "" {
+		return "", rows[0].Errorf("expected a disk map, got nothing")
+	}
+	if _, err := rows[0].Digits(); err != nil {
+		return "", err
+	}
+	return rows[0].Text, nil
 }
 
-func part1(diskMap string) int64 {
+func part1(diskMap string) (aoc.Answer, error) {
 	var fileBlocks []string
 	blockId := 0
 
@@ -74,8 +82,7 @@ func part1(diskMap string) int64 {
 	}
 
 	// fmt.Println(strings.Join(fileBlocks, ""))
-	fmt.Println(checksum)
-	return checksum
+	return aoc.Int(checksum), nil
 }
 
 type Block struct {
@@ -143,7 +150,7 @@ func slidingWindow(fileBlocks []string) []string {
 	return result
 }
 
-func part2(diskMap string) int64 {
+func part2(diskMap string) (aoc.Answer, error) {
 	var fileBlocks []string
 	blockId := 0
 
@@ -177,18 +184,5 @@ func part2(diskMap string) int64 {
 		checksum += int64(i) * int64(val)
 	}
 
-	fmt.Println(checksum)
-	return checksum
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(checksum), nil
 }
@@ -13,20 +13,15 @@ if [ ! -d "$TARGET_DIR" ]; then
 	exit 1
 fi
 
+REPO_ROOT="$(cd "$(dirname "$0")/.." && pwd)"
+DAY="$(basename "$(cd "$TARGET_DIR" && pwd)")"
+
 cd "$TARGET_DIR" || exit 1
 
 # Go build
 if [ -f "main.go" ]; then
 	echo "Building Go executables..."
-	go build -o advent_code_2024_go main.go
-
-	# Build comparison Go files
-	for file in alt-*.go; do
-		if [ -f "$file" ]; then
-			output_name="advent_code_2024_go_${file%.*}"
-			go build -o "$output_name" "$file"
-		fi
-	done
+	(cd "$REPO_ROOT" && go build -o "$OLDPWD/advent_code_2024_go" ./cmd/aoc)
 fi
 
 # Rust build
@@ -74,19 +69,15 @@ if [ ! -f "main.go" ]; then
 	echo "❌ - I Gon't"
 else
 	echo "[Default]"
-	./advent_code_2024_go # Warmup
-	{ time ./advent_code_2024_go; } 2>&1 | grep real | awk '{printf "🚀 Single run - %s\n", $2}'
-	{ time for i in $(seq 1 $RUNS); do ./advent_code_2024_go >/dev/null 2>&1; done; } 2>&1 | grep real | awk '{printf "🚀 Total all runs - %s\n", $2}'
-
-	# Run comparison Go files
-	for file in advent_code_2024_go_alt-*; do
-		if [ -f "$file" ]; then
-			echo "[Alternative (${file#advent_code_2024_go_})]"
-			./"$file" # Warmup
-			{ time ./"$file"; } 2>&1 | grep real | awk '{printf "🚀 Single run - %s\n", $2}'
-			{ time for i in $(seq 1 $RUNS); do ./"$file" >/dev/null 2>&1; done; } 2>&1 | grep real | awk '{printf "🚀 Total all runs - %s\n", $2}'
-		fi
-	done
+	./advent_code_2024_go run 2024 "$DAY" # Warmup
+	{ time ./advent_code_2024_go run 2024 "$DAY"; } 2>&1 | grep real | awk '{printf "🚀 Single run - %s\n", $2}'
+	{ time for i in $(seq 1 $RUNS); do ./advent_code_2024_go run 2024 "$DAY" >/dev/null 2>&1; done; } 2>&1 | grep real | awk '{printf "🚀 Total all runs - %s\n", $2}'
+
+	# alt-*.go files register as variants of the day, bench times them side by side
+	if ls alt-*.go >/dev/null 2>&1; then
+		echo "[Alternatives]"
+		(cd "$REPO_ROOT" && "$OLDPWD/advent_code_2024_go" bench -n "$RUNS" -warmup 1 2024 "$DAY")
+	fi
 fi
 
 echo "------------------------------"
@@ -0,0 +1,12 @@
+module aoc2024
+
+go 1.23.3
+
+require (
+	aoc v0.0.0
+	github.com/eiannone/keyboard v0.0.0-20220611211555-0d226195f203
+)
+
+require golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
+
+replace aoc => ../aoc
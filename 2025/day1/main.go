@@ -1,44 +1,52 @@
-package main
+package day1
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
-	"strings"
+	"aoc"
+	"regexp"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(strings.TrimSpace(string(data)), "\n"), nil
+func init() {
+	aoc.Register(2025, 1, aoc.Solution[[]Rotation]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
+// Rotation turns the dial left or right by a distance.
+type Rotation struct {
+	direction byte
+	distance  int
+}
 
-	part1(data)
-	part2(data)
+var rotation = regexp.MustCompile(`([LR])(\d+)`)
+
+func formatData(in aoc.Input) ([]Rotation, error) {
+	var rotations []Rotation
+	for _, row := range in.Rows() {
+		matches, err := row.Match(rotation)
+		if err != nil {
+			return nil, err
+		}
+		distance, err := matches[1].Int()
+		if err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, Rotation{matches[0].Text[0], distance})
+	}
+	return rotations, nil
 }
 
-func part1(data []string) {
+func part1(data []Rotation) (aoc.Answer, error) {
 	count := 0
 	w := NewWheel()
 
-	for _, row := range data {
-		direction, distance := parseData(row)
-
-		switch direction {
+	for _, r := range data {
+		switch r.direction {
 		case 'L':
-			w.decrement(distance % w.max)
+			w.decrement(r.distance % w.max)
 		case 'R':
-			w.increment(distance % w.max)
+			w.increment(r.distance % w.max)
 		}
 
 		if w.pos == w.min {
@@ -46,30 +54,22 @@ func part1(data []string) {
 		}
 	}
 
-	fmt.Println("Part 1: ", count)
+	return aoc.Int(count), nil
 }
 
-func parseData(row string) (byte, int) {
-	direction := row[0]
-	distance, _ := strconv.Atoi(row[1:])
-	return direction, distance
-}
-
-func part2(data []string) {
+func part2(data []Rotation) (aoc.Answer, error) {
 	w := NewWheel()
 
-	for _, row := range data {
-		direction, distance := parseData(row)
-
-		switch direction {
+	for _, r := range data {
+		switch r.direction {
 		case 'L':
-			w.decrement(distance)
+			w.decrement(r.distance)
 		case 'R':
-			w.increment(distance)
+			w.increment(r.distance)
 		}
 	}
 
-	fmt.Println("Part 2: ", w.carry)
+	return aoc.Int(w.carry), nil
 }
 
 func NewWheel() *Wheel {
@@ -1,49 +1,88 @@
 package machine
 
 import (
+	"aoc"
+	"errors"
 	"fmt"
+	"math/bits"
+	"regexp"
 	"strings"
 )
 
 type Machine struct {
-	Lights  string
+	// Lights is the lights and the buttons wired to them, packed into bits.
+	Lights  Panel
 	Buttons [][]int
 	Joltage []int
 }
 
-func NewMachine(data string) Machine {
-	parts := strings.Split(data, " ")
+// Parse reads a machine off a line like "[.##.] (3) (1,3) (2) {3,5,4,7}":
+// the lights, the buttons with the lights they toggle and the joltages.
+func Parse(line aoc.Line) (Machine, error) {
+	parts := line.Fields()
+	if len(parts) < 2 {
+		return Machine{}, line.Errorf("expected lights, buttons and joltages, got %q", line.Text)
+	}
 
-	machine := &Machine{
-		Lights:  strings.Trim(parts[0], "[]"),
+	lights, err := parts[0].Match(lightsPattern)
+	if err != nil {
+		return Machine{}, err
+	}
+	if len(lights[0].Text) > 64 {
+		return Machine{}, parts[0].Errorf("%d lights, a machine has at most 64", len(lights[0].Text))
+	}
+	if len(parts)-2 > 64 {
+		return Machine{}, line.Errorf("%d buttons, a machine has at most 64", len(parts)-2)
+	}
+	machine := Machine{
+		Lights:  Panel{Size: len(lights[0].Text)},
 		Buttons: [][]int{},
 		Joltage: []int{},
 	}
+	for i, light := range lights[0].Text {
+		if light == '#' {
+			machine.Lights.Lights |= 1 << i
+		}
+	}
 
-	for _, part := range parts[1:] {
-		if strings.HasPrefix(part, "(") && strings.HasSuffix(part, ")") {
-			var buttonIndices []int
-			buttonStr := strings.Trim(part, "()")
-			for _, b := range strings.Split(buttonStr, ",") {
-				var index int
-				fmt.Sscanf(b, "%d", &index)
-				buttonIndices = append(buttonIndices, index)
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		switch {
+		case !last && strings.HasPrefix(part.Text, "(") && strings.HasSuffix(part.Text, ")"):
+			buttonIndices, err := part.Slice(1, len(part.Text)-1).Ints(",")
+			if err != nil {
+				return Machine{}, err
+			}
+			var button uint64
+			for _, index := range buttonIndices {
+				if index < 0 || index >= machine.Lights.Size {
+					return Machine{}, part.Errorf("button toggles light %d, there are %d", index, machine.Lights.Size)
+				}
+				button |= 1 << index
 			}
 			machine.Buttons = append(machine.Buttons, buttonIndices)
-		} else if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
-			var jolt int
-			joltStr := strings.Trim(part, "{}")
-			for _, j := range strings.Split(joltStr, ",") {
-				fmt.Sscanf(j, "%d", &jolt)
-				machine.Joltage = append(machine.Joltage, jolt)
+			machine.Lights.Buttons = append(machine.Lights.Buttons, button)
+		case last && strings.HasPrefix(part.Text, "{") && strings.HasSuffix(part.Text, "}"):
+			machine.Joltage, err = part.Slice(1, len(part.Text)-1).Ints(",")
+			if err != nil {
+				return Machine{}, err
+			}
+			if len(machine.Joltage) != machine.Lights.Size {
+				return Machine{}, part.Errorf("%d joltages for %d lights", len(machine.Joltage), machine.Lights.Size)
 			}
+		case last:
+			return Machine{}, part.Errorf("expected joltages like {3,5,4,7}, got %q", part.Text)
+		default:
+			return Machine{}, part.Errorf("expected a button like (1,3), got %q", part.Text)
 		}
 	}
-	return *machine
+	return machine, nil
 }
 
+var lightsPattern = regexp.MustCompile(`\[([.#]+)\]`)
+
 func (m *Machine) IsOn() bool {
-	return strings.Count(m.Lights, "#") == 0
+	return m.Lights.IsOn()
 }
 
 func (m *Machine) IsPowered() bool {
@@ -55,31 +94,155 @@ func (m *Machine) IsPowered() bool {
 	return true
 }
 
+// Toggle presses a button, flipping the lights it is wired to.
 func (m *Machine) Toggle(buttonIndex int) {
 	if buttonIndex < 0 || buttonIndex >= len(m.Buttons) {
 		return
 	}
-	button := m.Buttons[buttonIndex]
+	m.Lights = m.Lights.Press(buttonIndex)
+}
+
+// Panel is the lights of a machine and its buttons packed into bits, bit i
+// for light i, so pressing a button is a single XOR.
+type Panel struct {
+	// Size is how many lights there are.
+	Size int
+	// Lights has the bits of the lights that are on set.
+	Lights uint64
+	// Buttons are the lights each button toggles.
+	Buttons []uint64
+}
+
+// Press is the panel after pressing button b.
+func (p Panel) Press(b int) Panel {
+	p.Lights ^= p.Buttons[b]
+	return p
+}
+
+// IsOn tells whether every light is off, like Machine.IsOn.
+func (p Panel) IsOn() bool {
+	return p.Lights == 0
+}
+
+// String draws the lights the way the input does, like "[.##.]" without the
+// brackets.
+func (p Panel) String() string {
+	lights := make([]byte, p.Size)
+	for i := range lights {
+		lights[i] = '.'
+		if p.Lights>>i&1 == 1 {
+			lights[i] = '#'
+		}
+	}
+	return string(lights)
+}
+
+// MaxFree is the most free buttons Solve tries every choice of.
+const MaxFree = 20
+
+// ErrNoSolution is a panel no presses turn off.
+var ErrNoSolution = errors.New("no buttons turn the lights off")
+
+// Solve is the fewest buttons to press, each once, to turn every light off.
+// It fails with ErrNoSolution when no presses can, and with another error when
+// more than MaxFree buttons are left free to try. Pressing a button twice undoes it, so this is
+// solving, over GF(2), one equation a light: the buttons wired to it add up to
+// 1 when it is on and 0 when it is off. Gaussian elimination leaves the
+// buttons that aren't pivots free, and every choice of them is tried for the
+// fewest presses, 2^free of them.
+func (p Panel) Solve() ([]int, error) {
+	rows, on, pivots := p.reduce()
+	var pivotMask uint64
+	for _, b := range pivots {
+		pivotMask |= 1 << b
+	}
+	for i := len(pivots); i < len(rows); i++ {
+		if on[i] {
+			return nil, ErrNoSolution
+		}
+	}
+	if free := len(p.Buttons) - len(pivots); free > MaxFree {
+		return nil, fmt.Errorf("%d buttons left free, too many to try every choice of, at most %d", free, MaxFree)
+	}
 
-	// Toggle lights
-	runes := []rune(m.Lights)
-	for _, idx := range button {
-		if idx < 0 || idx >= len(runes) {
+	// pressing the pivots of the lights left on, and no free button, works
+	var pressed uint64
+	for i, b := range pivots {
+		if on[i] {
+			pressed |= 1 << b
+		}
+	}
+	// pressing a free button as well means pressing again the pivots it is
+	// in the equations of
+	var free []uint64
+	for b := range p.Buttons {
+		if pivotMask>>b&1 == 1 {
 			continue
 		}
-		if runes[idx] == '#' {
-			runes[idx] = '.'
-		} else {
-			runes[idx] = '#'
+		presses := uint64(1) << b
+		for i, pivot := range pivots {
+			if rows[i]>>b&1 == 1 {
+				presses |= 1 << pivot
+			}
+		}
+		free = append(free, presses)
+	}
+
+	best := pressed
+	for choice := 1; choice < 1<<len(free); choice++ {
+		presses := pressed
+		for k, f := range free {
+			if choice>>k&1 == 1 {
+				presses ^= f
+			}
+		}
+		if bits.OnesCount64(presses) < bits.OnesCount64(best) {
+			best = presses
 		}
 	}
-	m.Lights = string(runes)
+
+	var buttons []int
+	for b := range p.Buttons {
+		if best>>b&1 == 1 {
+			buttons = append(buttons, b)
+		}
+	}
+	return buttons, nil
 }
 
-func (m *Machine) ToggleMask(joltageMultiplier []int) {
-	for i := range m.Joltage {
-		if i < len(joltageMultiplier) {
-			m.Joltage[i] = (m.Joltage[i] - joltageMultiplier[i]) / 2
+// reduce is Gaussian elimination over GF(2) of one equation a light, a row
+// with a bit for each button wired to it and whether the light is on. It
+// returns the rows reduced, the first ones led by the buttons in pivots.
+func (p Panel) reduce() (rows []uint64, on []bool, pivots []int) {
+	rows = make([]uint64, p.Size)
+	on = make([]bool, p.Size)
+	for light := range rows {
+		for b, button := range p.Buttons {
+			if button>>light&1 == 1 {
+				rows[light] |= 1 << b
+			}
+		}
+		on[light] = p.Lights>>light&1 == 1
+	}
+
+	for b := range p.Buttons {
+		rank := len(pivots)
+		r := rank
+		for r < len(rows) && rows[r]>>b&1 == 0 {
+			r++
+		}
+		if r == len(rows) {
+			continue
+		}
+		rows[rank], rows[r] = rows[r], rows[rank]
+		on[rank], on[r] = on[r], on[rank]
+		for i := range rows {
+			if i != rank && rows[i]>>b&1 == 1 {
+				rows[i] ^= rows[rank]
+				on[i] = on[i] != on[rank]
+			}
 		}
+		pivots = append(pivots, b)
 	}
+	return rows, on, pivots
 }
@@ -1,19 +1,16 @@
-package main
+package day12
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
+	"aoc"
+	"regexp"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n\n"), nil
+func init() {
+	aoc.Register(2025, 12, aoc.Solution[State]{
+		Parse: formatData,
+		Part1: part1,
+	})
 }
 
 type State struct {
@@ -32,59 +29,77 @@ type Area struct {
 	presentCounts []int
 }
 
-func formatData(rows []string) State {
-	var formatted State
-
-	for _, row := range rows {
+var (
+	presentHeader = regexp.MustCompile(`(\d+):`)
+	areaLine      = regexp.MustCompile(`(\d+)x(\d+): ([\d ]+)`)
+)
 
+func formatData(in aoc.Input) (State, error) {
+	var formatted State
+	for _, rows := range in.Sections() {
 		// presents
-		if !strings.Contains(row, "x") {
-			presentLines := strings.Split(strings.TrimSpace(row), "\n")[1:]
+		if !strings.Contains(rows[0].Text, "x") {
+			header, err := rows[0].Match(presentHeader)
+			if err != nil {
+				return State{}, err
+			}
+			index, err := header[0].Int()
+			if err != nil {
+				return State{}, err
+			}
+			if index != len(formatted.Presents) {
+				return State{}, header[0].Errorf("expected present %d, got %d", len(formatted.Presents), index)
+			}
+			shape, err := aoc.GridOf(in, rows[1:], "#.")
+			if err != nil {
+				return State{}, err
+			}
 			var present Present
-			for _, line := range presentLines {
-				presentRow := []rune(line)
-				present.shape = append(present.shape, presentRow)
-				present.size += strings.Count(line, "#")
+			for _, line := range shape {
+				present.shape = append(present.shape, []rune(line.Text))
+				present.size += strings.Count(line.Text, "#")
 			}
 			formatted.Presents = append(formatted.Presents, present)
 			continue
 		}
 
 		// areas
-		if strings.Contains(row, "x") {
-			parts := strings.Split(row, "\n")
-			for _, part := range parts {
-				subParts := strings.SplitN(part, ": ", 2)
-				if len(subParts) != 2 {
-					continue
-				}
-
-				var area Area
-				areaParts := strings.Split(strings.TrimSpace(subParts[0]), "x")
-				w, _ := strconv.Atoi(areaParts[0])
-				l, _ := strconv.Atoi(areaParts[1])
-				area.size = w * l
-				area.grid = make([][]rune, l)
-				for r := range l {
-					area.grid[r] = make([]rune, w)
-					for c := range w {
-						area.grid[r][c] = '.'
-					}
+		for _, row := range rows {
+			parts, err := row.Match(areaLine)
+			if err != nil {
+				return State{}, err
+			}
+			var area Area
+			w, err := parts[0].Int()
+			if err != nil {
+				return State{}, err
+			}
+			l, err := parts[1].Int()
+			if err != nil {
+				return State{}, err
+			}
+			area.size = w * l
+			area.grid = make([][]rune, l)
+			for r := range l {
+				area.grid[r] = make([]rune, w)
+				for c := range w {
+					area.grid[r][c] = '.'
 				}
+			}
 
-				presentsStr := strings.Split(strings.TrimSpace(subParts[1]), " ")
-				for _, ps := range presentsStr {
-					p, _ := strconv.Atoi(ps)
-					area.presentCounts = append(area.presentCounts, p)
-				}
-				formatted.Areas = append(formatted.Areas, area)
+			if area.presentCounts, err = parts[2].Ints(""); err != nil {
+				return State{}, err
 			}
+			if len(area.presentCounts) != len(formatted.Presents) {
+				return State{}, parts[2].Errorf("expected a count for each of the %d presents, got %d", len(formatted.Presents), len(area.presentCounts))
+			}
+			formatted.Areas = append(formatted.Areas, area)
 		}
 	}
-	return formatted
+	return formatted, nil
 }
 
-func part1(data State) {
+func part1(data State) (aoc.Answer, error) {
 	sum := 0
 
 	for _, area := range data.Areas {
@@ -98,16 +113,5 @@ func part1(data State) {
 			sum++
 		}
 	}
-	fmt.Println("Part 1:", sum)
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
+	return aoc.Int(sum), nil
 }
@@ -1,51 +1,57 @@
-package main
+package day2
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
+	"aoc"
+	"aoc/interval"
 	"strconv"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), ","), nil
+func init() {
+	aoc.Register(2025, 2, aoc.Solution[*interval.Set]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) [][]int {
-	intervals := make([][]int, len(rows))
-
-	for i, row := range rows {
-		intervalStr := strings.Split(row, "-")
-		firstId, _ := strconv.Atoi(intervalStr[0])
-		lastId, _ := strconv.Atoi(intervalStr[1])
-		intervals[i] = getIdsInRange(firstId, lastId)
+// formatData reads the ID ranges into a set, so an ID in two ranges that
+// overlap is only checked once.
+func formatData(in aoc.Input) (*interval.Set, error) {
+	lines := in.Rows()
+	if len(lines) != 1 {
+		return nil, lines[0].Errorf("expected the ranges on a single line")
 	}
+	rows := lines[0].Split(",")
+	ranges := &interval.Set{}
 
-	return intervals
-}
-
-func getIdsInRange(firstId int, lastId int) []int {
-	allIds := []int{}
-
-	for i := firstId; i <= lastId; i++ {
-		allIds = append(allIds, i)
+	for _, row := range rows {
+		ids, err := row.SplitN("-", 2)
+		if err != nil {
+			return nil, err
+		}
+		firstId, err := ids[0].Int()
+		if err != nil {
+			return nil, err
+		}
+		lastId, err := ids[1].Int()
+		if err != nil {
+			return nil, err
+		}
+		if firstId > lastId {
+			return nil, row.Errorf("range %s ends before it starts", row)
+		}
+		ranges.Add(interval.Closed(firstId, lastId))
 	}
 
-	return allIds
+	return ranges, nil
 }
 
-func part1(data [][]int) {
-	invalidIds := [][]int{}
+func part1(ranges *interval.Set) (aoc.Answer, error) {
 	sum := 0
 
-	for i, interval := range data {
-		invalidIds = append(invalidIds, []int{})
-		for _, id := range interval {
+	for _, idRange := range ranges.Intervals() {
+		for id := range idRange.Values() {
 			idStr := strconv.Itoa(id)
 			if len(idStr)%2 != 0 {
 				continue
@@ -56,30 +62,26 @@ func part1(data [][]int) {
 			right := idStr[middle:]
 
 			if left == right {
-				invalidIds[i] = append(invalidIds[i], id)
 				sum += id
 			}
 		}
 	}
 
-	fmt.Println("Part 1:", sum)
+	return aoc.Int(sum), nil
 }
 
-func part2(data [][]int) {
-	invalidIds := [][]int{}
+func part2(ranges *interval.Set) (aoc.Answer, error) {
 	sum := 0
 
-	for i, interval := range data {
-		invalidIds = append(invalidIds, []int{})
-		for _, id := range interval {
+	for _, idRange := range ranges.Intervals() {
+		for id := range idRange.Values() {
 			if hasRepeatedSequence(strconv.Itoa(id)) {
-				invalidIds[i] = append(invalidIds[i], id)
 				sum += id
 			}
 		}
 	}
 
-	fmt.Println("Part 2:", sum)
+	return aoc.Int(sum), nil
 }
 
 func hasRepeatedSequence(id string) bool {
@@ -88,15 +90,3 @@ func hasRepeatedSequence(id string) bool {
 	hasId := strings.Contains(trimmed, id)
 	return hasId
 }
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
-}
@@ -1,29 +1,50 @@
-package main
+package day3
 
 import (
+	"aoc"
 	"aoc2025/day3/utils"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2025, 3, aoc.Solution[Banks]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) []string {
-	return rows
+// Banks are the battery banks, along with how many batteries to turn on in
+// each bank for part 2.
+type Banks struct {
+	banks  []string
+	length int
+}
+
+func formatData(in aoc.Input) (Banks, error) {
+	length, err := in.Int("length", 12)
+	if err != nil {
+		return Banks{}, err
+	}
+	rows := in.Rows()
+	banks := make([]string, len(rows))
+	for i, row := range rows {
+		if len(row.Text) < length {
+			return Banks{}, row.Errorf("expected a bank of at least %d batteries, got %d", length, len(row.Text))
+		}
+		if _, err := row.Digits(); err != nil {
+			return Banks{}, err
+		}
+		banks[i] = row.Text
+	}
+	return Banks{banks, length}, nil
 }
 
-func part1(banks []string) {
+func part1(data Banks) (aoc.Answer, error) {
+	banks := data.banks
 	sum := 0
 
 	for _, bank := range banks {
@@ -56,12 +77,12 @@ func part1(banks []string) {
 		// fmt.Println("Highest number:", highestNumber)
 		sum += highestNumber
 	}
-	fmt.Println("Part 1:", sum)
+	return aoc.Int(sum), nil
 }
 
-func part2(banks []string) {
+func part2(data Banks) (aoc.Answer, error) {
+	banks, length := data.banks, data.length
 	sum := 0
-	length := 12
 
 	for _, bank := range banks {
 		if len(bank) < length {
@@ -71,7 +92,11 @@ func part2(banks []string) {
 		joltageNum, _ := processBank(bank, length, nil, 0)
 		sum += joltageNum
 	}
-	fmt.Println("Part 2:", sum)
+
+	if aoc.Visual() {
+		part2visualAsync(banks, length)
+	}
+	return aoc.Int(sum), nil
 }
 
 func processBank(bank string, length int, render *utils.Visualiser, bankIdx int) (int, map[int]bool) {
@@ -108,8 +133,7 @@ func processBank(bank string, length int, render *utils.Visualiser, bankIdx int)
 	return joltageNum, usedIndexes
 }
 
-func part2visualAsync(banks []string) {
-	length := 12
+func part2visualAsync(banks []string, length int) {
 	render := utils.NewVisualiser(10*time.Millisecond, false)
 
 	validBanks := []struct {
@@ -151,20 +175,3 @@ func part2visualAsync(banks []string) {
 	wg.Wait()
 	fmt.Println("\nSum of max values:", sum)
 }
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
-
-	withVisual := os.Getenv("AOC_VISUAL") == "1"
-	if withVisual {
-		part2visualAsync(formattedData)
-	}
-}
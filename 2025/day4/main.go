@@ -1,71 +1,55 @@
-package main
+package day4
 
 import (
+	"aoc"
+	"aoc/grid"
+	"aoc/point"
 	"aoc2025/utils"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 	"time"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
+func init() {
+	aoc.Register(2025, 4, aoc.Solution[*grid.Grid[string]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func formatData(in aoc.Input) (*grid.Grid[string], error) {
+	rows, err := in.Grid(".@")
 	if err != nil {
 		return nil, err
 	}
-	return strings.Split(string(data), "\n"), nil
+	return grid.Strings(rows)
 }
 
-func formatData(rows []string) [][]string {
-	grid := make([][]string, len(rows))
-
-	for r, c := range rows {
-		grid[r] = strings.Split(c, "")
+// accessible tells whether the roll at cell has fewer than four rolls around it.
+func accessible(floor *grid.Grid[string], cell int) bool {
+	rollsCount := 0
+	for neighbour := range floor.Neighbours(cell, point.All) {
+		if floor.At(neighbour) == "@" {
+			rollsCount++
+		}
 	}
-
-	return grid
+	return rollsCount < 4
 }
 
-func part1(grid [][]string) {
+func part1(floor *grid.Grid[string]) (aoc.Answer, error) {
 	totalRolls := 0
 
-	for r := range grid {
-		for c := range grid[r] {
-
-			coords := [2]int{r, c}
-			cell := grid[r][c]
-
-			if cell != "@" {
-				continue
-			}
-
-			directions := [][]int{{0, -1}, {-1, -1}, {-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}}
-			rollsCount := 0
-
-			for _, direction := range directions {
-				row := coords[0] + direction[0]
-				col := coords[1] + direction[1]
-
-				if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[0]) {
-					continue
-				}
-
-				if grid[row][col] == "@" {
-					rollsCount++
-				}
-			}
-			if rollsCount < 4 {
-				totalRolls++
-			}
+	for cell, value := range floor.Cells {
+		if value == "@" && accessible(floor, cell) {
+			totalRolls++
 		}
 	}
 
-	fmt.Println("Part 1:", totalRolls)
-
+	return aoc.Int(totalRolls), nil
 }
 
-func part2(grid [][]string, withVisual bool) {
+func part2(floor *grid.Grid[string]) (aoc.Answer, error) {
+	withVisual := aoc.Visual()
 	totalRolls := 0
 	lastCount := -1
 
@@ -78,49 +62,20 @@ func part2(grid [][]string, withVisual bool) {
 		lastCount = totalRolls
 
 		if withVisual {
-			renderGrid(grid)
+			renderGrid(floor)
 		}
-		for r := range grid {
-			for c := range grid[r] {
-
-				coords := [2]int{r, c}
-				cell := grid[r][c]
-
-				if cell != "@" {
-					continue
-				}
-
-				directions := [][]int{{0, -1}, {-1, -1}, {-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}}
-				rollsCount := 0
-
-				for _, direction := range directions {
-					row := coords[0] + direction[0]
-					col := coords[1] + direction[1]
-
-					if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[0]) {
-						continue
-					}
-
-					if grid[row][col] == "@" {
-						rollsCount++
-					}
-				}
-				if rollsCount < 4 {
-					totalRolls++
-					grid[r][c] = "x"
-				}
+		for cell, value := range floor.Cells {
+			if value == "@" && accessible(floor, cell) {
+				totalRolls++
+				floor.Set(cell, "x")
 			}
 		}
 
 		if withVisual {
-			renderGrid(grid)
+			renderGrid(floor)
 			// replace `x` with `.` for smoother visual
-			for r := range grid {
-				for c := range grid[r] {
-					if grid[r][c] == "x" {
-						grid[r][c] = "."
-					}
-				}
+			for _, cell := range floor.FindAll(func(value string) bool { return value == "x" }) {
+				floor.Set(cell, ".")
 			}
 		}
 	}
@@ -128,10 +83,10 @@ func part2(grid [][]string, withVisual bool) {
 		utils.ExitVisualMode()
 	}
 
-	fmt.Println("Part 2:", totalRolls)
+	return aoc.Int(totalRolls), nil
 }
 
-func renderGrid(grid [][]string) {
+func renderGrid(floor *grid.Grid[string]) {
 	cellRenderer := func(ctx utils.CellRenderContext) string {
 		switch ctx.Cell {
 		case ".":
@@ -145,20 +100,6 @@ func renderGrid(grid [][]string) {
 		}
 	}
 
-	utils.RenderGrid(grid, -1, -1, nil, cellRenderer)
+	utils.RenderGrid(floor, -1, nil, cellRenderer)
 	time.Sleep(100 * time.Millisecond)
 }
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	withVisual := os.Getenv("AOC_VISUAL") == "1"
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData, withVisual)
-}
@@ -1,112 +1,71 @@
-package main
+package day5
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"sort"
-	"strconv"
-	"strings"
+	"aoc"
+	"aoc/interval"
 )
 
-func readData() ([2][]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return [2][]string{}, err
-	}
-	result := strings.Split(string(data), "\n\n")
-	part1 := strings.Split(result[0], "\n")
-	part2 := strings.Split(result[1], "\n")
-	return [2][]string{part1, part2}, nil
+func init() {
+	aoc.Register(2025, 5, aoc.Solution[Database]{
+		Parse: formatData,
+		Part1: func(data Database) (aoc.Answer, error) { return part1(data.fresh, data.ingredients) },
+		Part2: func(data Database) (aoc.Answer, error) { return part2(data.fresh) },
+	})
 }
 
-func part1(intervals []string, ingredients []string) {
-	freshCount := 0
+// Database holds the fresh ingredient ID ranges and the available IDs.
+type Database struct {
+	fresh       *interval.Set
+	ingredients []int
+}
 
-	intervalsList := make([]Interval, 0, len(intervals))
-	for _, interval := range intervals {
-		parts := strings.Split(interval, "-")
-		start, _ := strconv.Atoi(parts[0])
-		end, _ := strconv.Atoi(parts[1])
-		intervalsList = append(intervalsList, Interval{Start: start, End: end})
+func formatData(in aoc.Input) (Database, error) {
+	data := Database{fresh: &interval.Set{}}
+	sections, err := in.SectionsN(2)
+	if err != nil {
+		return Database{}, err
 	}
 
-	mergedIntervals := mergeIntervals(intervalsList)
-
-	for _, ingredient := range ingredients {
-		ingredientId, _ := strconv.Atoi(ingredient)
-
-		for _, interval := range mergedIntervals {
-			// check if it's in range
-			if ingredientId >= interval.Start && ingredientId <= interval.End {
-				freshCount++
-				break
-			}
+	for _, row := range sections[0] {
+		bounds, err := row.SplitN("-", 2)
+		if err != nil {
+			return Database{}, err
 		}
+		start, err := bounds[0].Int()
+		if err != nil {
+			return Database{}, err
+		}
+		end, err := bounds[1].Int()
+		if err != nil {
+			return Database{}, err
+		}
+		if start > end {
+			return Database{}, row.Errorf("range %s ends before it starts", row)
+		}
+		data.fresh.Add(interval.Closed(start, end))
 	}
 
-	fmt.Println("Part 1:", freshCount)
-}
-
-type Interval struct{ Start, End int }
-
-func mergeIntervals(intervals []Interval) []Interval {
-	if len(intervals) == 0 {
-		return nil
-	}
-
-	// order by start
-	sort.Slice(intervals, func(a, b int) bool { return intervals[a].Start < intervals[b].Start })
-
-	merged := make([]Interval, 0, len(intervals))
-	curr := intervals[0]
-
-	for _, next := range intervals[1:] {
-		// extend on overlap or adjacent
-		if next.Start <= curr.End {
-			if next.End > curr.End {
-				curr.End = next.End
-			}
-			continue
+	for _, row := range sections[1] {
+		id, err := row.Int()
+		if err != nil {
+			return Database{}, err
 		}
-		// concat on non-overlapping
-		merged = append(merged, curr)
-		curr = next
+		data.ingredients = append(data.ingredients, id)
 	}
-
-	// add last
-	merged = append(merged, curr)
-	return merged
+	return data, nil
 }
 
-func part2(intervals []string) {
-	idCount := 0
-
-	intervalsList := make([]Interval, 0, len(intervals))
-	for _, interval := range intervals {
-		parts := strings.Split(interval, "-")
-		start, _ := strconv.Atoi(parts[0])
-		end, _ := strconv.Atoi(parts[1])
-		intervalsList = append(intervalsList, Interval{Start: start, End: end})
-	}
-
-	mergedIntervals := mergeIntervals(intervalsList)
-
-	for _, interval := range mergedIntervals {
-		idCount += (interval.End - interval.Start + 1)
+func part1(fresh *interval.Set, ingredients []int) (aoc.Answer, error) {
+	freshCount := 0
+	for _, ingredientId := range ingredients {
+		if fresh.Contains(ingredientId) {
+			freshCount++
+		}
 	}
 
-	fmt.Println("Part 2:", idCount)
-
+	return aoc.Int(freshCount), nil
 }
 
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	part1(data[0], data[1])
-	part2(data[0])
+func part2(fresh *interval.Set) (aoc.Answer, error) {
+	return aoc.Int(fresh.Len()), nil
 }
@@ -1,26 +1,50 @@
-package main
+package day6
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
+	"aoc"
 	"strconv"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2025, 6, aoc.Solution[[]string]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) []string {
-	return rows
+func formatData(in aoc.Input) ([]string, error) {
+	rows := in.Rows()
+	if len(rows) < 2 {
+		return nil, in.Errorf("expected rows of numbers followed by a row of operators")
+	}
+
+	operators := rows[len(rows)-1].Fields()
+	for _, op := range operators {
+		if op.Text != "*" && op.Text != "+" {
+			return nil, op.Errorf("expected * or +, got %q", op)
+		}
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = row.Text
+		if i == len(rows)-1 {
+			continue
+		}
+		numbers, err := row.Ints("")
+		if err != nil {
+			return nil, err
+		}
+		if len(numbers) != len(operators) {
+			return nil, row.Errorf("expected %d numbers, one per operator, got %d", len(operators), len(numbers))
+		}
+	}
+	return lines, nil
 }
 
-func part1(data []string) {
+func part1(data []string) (aoc.Answer, error) {
 	operators := strings.Fields(data[len(data)-1])
 	numbersRows := data[:len(data)-1]
 
@@ -54,10 +78,10 @@ func part1(data []string) {
 	for _, val := range exams {
 		sum += val
 	}
-	fmt.Println("Part 1:", sum)
+	return aoc.Int(sum), nil
 }
 
-func part2(data []string) {
+func part2(data []string) (aoc.Answer, error) {
 	operators := strings.Fields(data[len(data)-1])
 	rows := data[:len(data)-1]
 
@@ -105,17 +129,5 @@ func part2(data []string) {
 	for _, val := range exams {
 		sum += val
 	}
-	fmt.Println("Part 2:", sum)
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	formattedData := formatData(data)
-	part1(formattedData)
-	part2(formattedData)
+	return aoc.Int(sum), nil
 }
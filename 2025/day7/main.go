@@ -1,54 +1,62 @@
-package main
+package day7
 
 import (
+	"aoc"
+	"aoc/grid"
+	"aoc/memo"
 	"aoc2025/utils"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
+func init() {
+	aoc.Register(2025, 7, aoc.Solution[*grid.Grid[string]]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func formatData(in aoc.Input) (*grid.Grid[string], error) {
+	rows, err := in.Grid(".S^")
 	if err != nil {
 		return nil, err
 	}
-	return strings.Split(string(data), "\n"), nil
+	return grid.Strings(rows)
 }
 
-func formatData(rows []string) [][]string {
-	formatted := make([][]string, len(rows))
-	for i, row := range rows {
-		formatted[i] = strings.Split(row, "")
+func part1(data *grid.Grid[string]) (aoc.Answer, error) {
+	withVisual := aoc.Visual()
+	if withVisual {
+		utils.EnterVisualMode()
+		defer utils.ExitVisualMode()
 	}
-	return formatted
-}
 
-func part1(data [][]string, withVisual bool) {
 	manifoldDiagram := data
+	at := func(row, col int) string { return manifoldDiagram.At(manifoldDiagram.Index(row, col)) }
+	set := func(row, col int, value string) { manifoldDiagram.Set(manifoldDiagram.Index(row, col), value) }
 	count := 0
 
-	beamRange := [2]int{0, len(manifoldDiagram) - 1}
+	beamRange := [2]int{0, manifoldDiagram.Width - 1}
 
-	for row := range manifoldDiagram {
+	for row := range manifoldDiagram.Height {
 		for col := beamRange[0]; col <= beamRange[1]; col++ {
 			isBeam := false
+			if !manifoldDiagram.In(row, col) {
+				continue
+			}
 			if withVisual {
-				utils.RenderGrid(manifoldDiagram, row, col, nil, cellRenderer)
+				utils.RenderGrid(manifoldDiagram, manifoldDiagram.Index(row, col), nil, cellRenderer)
 			}
-			if manifoldDiagram[row][col] == "S" {
+			if at(row, col) == "S" {
 				beamRange = [2]int{col, col}
 				break
 			}
 
-			if row < 0 || row >= len(manifoldDiagram) || col < 0 || col >= len(manifoldDiagram[0]) {
-				continue
-			}
-
-			if manifoldDiagram[row][col] == "^" && manifoldDiagram[row-1][col] == "|" {
+			if at(row, col) == "^" && at(row-1, col) == "|" {
 				isBeam = true
-				manifoldDiagram[row][col-1] = "|"
-				manifoldDiagram[row][col+1] = "|"
+				set(row, col-1, "|")
+				set(row, col+1, "|")
 				if beamRange[0] > col-1 {
 					beamRange[0] = col - 1
 				}
@@ -59,17 +67,17 @@ func part1(data [][]string, withVisual bool) {
 				continue
 			}
 
-			if isBeam || manifoldDiagram[row][col] == "S" || row == 0 {
+			if isBeam || at(row, col) == "S" || row == 0 {
 				continue
 			}
 
-			if manifoldDiagram[row-1][col] == "S" {
-				manifoldDiagram[row][col] = "|"
+			if at(row-1, col) == "S" {
+				set(row, col, "|")
 				beamRange = [2]int{col, col}
 				continue
 			}
-			if manifoldDiagram[row-1][col] == "|" && manifoldDiagram[row][col] != "^" {
-				manifoldDiagram[row][col] = "|"
+			if at(row-1, col) == "|" && at(row, col) != "^" {
+				set(row, col, "|")
 				continue
 			}
 
@@ -78,108 +86,87 @@ func part1(data [][]string, withVisual bool) {
 
 	if withVisual {
 		fmt.Print(utils.ClearScreen + utils.MoveCursor)
-		utils.RenderGrid(manifoldDiagram, -1, -1, nil, cellRenderer)
+		utils.RenderGrid(manifoldDiagram, -1, nil, cellRenderer)
 	}
-	fmt.Println("Part 1:", count)
+	return aoc.Int(count), nil
 }
 
-func part2(data [][]string, withVisual bool) {
+func part2(data *grid.Grid[string]) (aoc.Answer, error) {
+	withVisual := aoc.Visual()
+	if withVisual {
+		utils.EnterVisualMode()
+		defer utils.ExitVisualMode()
+	}
+
 	manifoldDiagram := data
 
-	var startRow, startCol int
-	for c := range manifoldDiagram[0] {
-		if manifoldDiagram[0][c] == "S" {
-			startRow = 0
-			startCol = c
-			break
-		}
-	}
+	start, _ := manifoldDiagram.Find(func(cell string) bool { return cell == "S" })
+	startRow, startCol := manifoldDiagram.Pos(start)
 
-	var render func(grid [][]string, row int, col int, activePath map[string]bool)
+	var render Render
 	if withVisual {
 		frameCounter := 0
-		render = func(grid [][]string, row, col int, activePath map[string]bool) {
+		render = func(g *grid.Grid[string], active int, activePath map[int]bool) {
 			if frameCounter%2 == 0 {
-				utils.RenderGrid(grid, row, col, activePath, cellRenderer)
+				utils.RenderGrid(g, active, activePath, cellRenderer)
 			}
 			frameCounter++
 		}
-	} else {
-		render = nil
 	}
 
-	totalPaths := dfsCountPath(manifoldDiagram, startRow+1, startCol, make(map[string]int), make(map[string]bool), make(map[string]bool), render)
+	totalPaths := dfsCountPath(manifoldDiagram, startRow+1, startCol, memo.New[uint64, int](), make(map[int]bool), make(map[int]bool), render)
 
 	if withVisual {
 		fmt.Print(utils.ClearScreen + utils.MoveCursor)
-		utils.RenderGrid(manifoldDiagram, -1, -1, nil, cellRenderer)
+		utils.RenderGrid(manifoldDiagram, -1, nil, cellRenderer)
 	}
-	fmt.Println("Part 2:", totalPaths)
+	return aoc.Int(totalPaths), nil
 }
 
-type Render func(grid [][]string, row, col int, activePath map[string]bool)
-
-func dfsCountPath(grid [][]string, row, col int, memo map[string]int, visited map[string]bool, activePath map[string]bool, render Render) int {
-	key := fmt.Sprintf("%d_%d", row, col)
+type Render func(g *grid.Grid[string], active int, activePath map[int]bool)
 
+func dfsCountPath(g *grid.Grid[string], row, col int, paths *memo.Table[uint64, int], visited map[int]bool, activePath map[int]bool, render Render) int {
 	// base
-	if row >= len(grid) {
+	if row >= g.Height {
 		return 1
 	}
-	if col < 0 || col >= len(grid[0]) {
+	if col < 0 || col >= g.Width {
 		return 0
 	}
-	if val, exists := memo[key]; exists {
+	key := g.Index(row, col)
+	pathsKey := memo.Pack2(row, col)
+	if val, exists := paths.Get(pathsKey); exists {
 		return val
 	}
 
 	activePath[key] = true
 	if render != nil {
-		render(grid, row, col, activePath)
+		render(g, key, activePath)
 	}
 
-	activeCell := grid[row][col]
+	activeCell := g.At(key)
 	if !visited[key] {
 		visited[key] = true
 		if activeCell == "." {
-			grid[row][col] = "⏐"
+			g.Set(key, "⏐")
 		}
 	}
 
 	// recursive
 	cell := activeCell
-	paths := 0
+	count := 0
 	switch cell {
 	case "^":
-		leftPaths := dfsCountPath(grid, row+1, col-1, memo, visited, activePath, render)
-		rightPaths := dfsCountPath(grid, row+1, col+1, memo, visited, activePath, render)
-		paths = leftPaths + rightPaths
+		leftPaths := dfsCountPath(g, row+1, col-1, paths, visited, activePath, render)
+		rightPaths := dfsCountPath(g, row+1, col+1, paths, visited, activePath, render)
+		count = leftPaths + rightPaths
 	default:
-		paths = dfsCountPath(grid, row+1, col, memo, visited, activePath, render)
+		count = dfsCountPath(g, row+1, col, paths, visited, activePath, render)
 	}
 
-	memo[key] = paths
+	paths.Set(pathsKey, count)
 	activePath[key] = false
-	return paths
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	withVisual := os.Getenv("AOC_VISUAL") == "1"
-
-	if withVisual {
-		utils.EnterVisualMode()
-		defer utils.ExitVisualMode()
-	}
-
-	part1(formatData(data), withVisual)
-
-	part2(formatData(data), withVisual)
+	return count
 }
 
 func cellRenderer(ctx utils.CellRenderContext) string {
@@ -1,184 +1,116 @@
-package main
+package day8
 
 import (
+	"aoc"
+	"aoc/disjoint"
+	"aoc/grid"
+	"aoc/point"
 	"aoc2025/utils"
+	"errors"
 	"fmt"
-	"math"
-	"os"
-	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
-}
-
-func formatData(rows []string) []Vector {
-	var vectors []Vector
-	for _, row := range rows {
-		parts := strings.Split(row, ",")
-		x, _ := strconv.ParseFloat(parts[0], 64)
-		y, _ := strconv.ParseFloat(parts[1], 64)
-		z, _ := strconv.ParseFloat(parts[2], 64)
-		vectors = append(vectors, Vector{X: x, Y: y, Z: z})
-	}
-	return vectors
-}
-
-type Vector struct {
-	X, Y, Z float64
-}
-
-func (a Vector) DistanceSq(b Vector) float64 {
-	dx := a.X - b.X
-	dy := a.Y - b.Y
-	dz := a.Z - b.Z
-	return dx*dx + dy*dy + dz*dz
-}
-
-func (a Vector) Distance(b Vector) float64 {
-	return math.Sqrt(a.DistanceSq(b))
-}
-
-type Pair struct {
-	U, V     Vector
-	Distance float64
+func init() {
+	aoc.Register(2025, 8, aoc.Solution[Playground]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-type Circuits struct {
-	counts  []int
-	circuit []int
+// Playground holds the junction boxes and how many of the closest pairs get
+// connected in part 1.
+type Playground struct {
+	junctionBoxes   JunctionBoxes
+	connectionCount int
 }
 
-func NewCircuitsManager(size int) *Circuits {
-	circuit := make([]int, size)
-	group := make([]int, size)
-	for i := range size {
-		circuit[i] = i
-		group[i] = 1
-	}
-	return &Circuits{
-		counts:  group,
-		circuit: circuit,
-	}
-}
-
-func (c *Circuits) Search(junctionBox int) int {
-	// base: junction box is its own group
-	if c.circuit[junctionBox] == junctionBox {
-		return junctionBox
+func formatData(in aoc.Input) (Playground, error) {
+	connectionCount, err := in.Int("connectionCount", 1000)
+	if err != nil {
+		return Playground{}, err
 	}
-	// recursive: keep searching
-	root := c.Search(c.circuit[junctionBox])
-	c.circuit[junctionBox] = root
-	return root
-}
 
-func (c *Circuits) Connect(u, v int) {
-	circuitU := c.Search(u)
-	circuitV := c.Search(v)
-	if circuitU == circuitV {
-		return // "already in the same circuit, nothing happens!"
-	}
-	// order by size
-	if c.counts[circuitU] < c.counts[circuitV] {
-		circuitU, circuitV = circuitV, circuitU // swap
+	var vectors JunctionBoxes
+	for _, row := range in.Rows() {
+		junctionBox, err := point.Parse3(row)
+		if err != nil {
+			return Playground{}, err
+		}
+		vectors = append(vectors, junctionBox)
 	}
-
-	c.circuit[circuitV] = circuitU
-	c.counts[circuitU] += c.counts[circuitV]
+	return Playground{vectors, connectionCount}, nil
 }
 
-func part1(junctionBoxes JunctionBoxes, withVisual bool) {
+func part1(playground Playground) (aoc.Answer, error) {
+	junctionBoxes := playground.junctionBoxes
+	withVisual := aoc.Visual()
 
 	pairs := junctionBoxes.buildPairs()
-	jbIdxMap := junctionBoxes.buildIndexMap()
-
-	connectionCount := 1000
-	manager := NewCircuitsManager(len(junctionBoxes))
-
-	for _, pair := range pairs {
-		if connectionCount <= 0 {
-			break
-		}
-		u := jbIdxMap[pair.U]
-		v := jbIdxMap[pair.V]
-
-		manager.Connect(u, v)
-		connectionCount--
-	}
-
-	circuitsMap := make(map[int][]Vector)
-	multiplyTop3 := 1
-
-	for i := range junctionBoxes {
-		cId := manager.Search(i)
-		circuitsMap[cId] = append(circuitsMap[cId], junctionBoxes[i])
+	circuitSet := disjoint.New(len(junctionBoxes))
+	for _, pair := range pairs[:min(playground.connectionCount, len(pairs))] {
+		circuitSet.Union(pair.U, pair.V)
 	}
 
-	var circuits [][]Vector
-	for _, circuit := range circuitsMap {
+	var circuits [][]point.Point3
+	for _, members := range circuitSet.Components() {
+		circuit := make([]point.Point3, len(members))
+		for i, member := range members {
+			circuit[i] = junctionBoxes[member]
+		}
 		circuits = append(circuits, circuit)
 	}
 	sort.Slice(circuits, func(a, b int) bool {
 		return len(circuits[a]) > len(circuits[b])
 	})
 
+	multiplyTop3 := 1
+	top3 := 3
+	for i := 0; i < top3 && i < len(circuits); i++ {
+		multiplyTop3 *= len(circuits[i])
+	}
+
 	if withVisual {
-		top3 := 3
 		renderCircuits(circuits, top3)
 
 		var buf strings.Builder
 		buf.WriteString(fmt.Sprintf("%d circuits found\n", len(circuits)))
 
 		for i := 0; i < top3 && i < len(circuits); i++ {
-			multiplyTop3 *= len(circuits[i])
 			buf.WriteString(fmt.Sprintf("Circuit #%d -> %d junction boxes\n", i+1, len(circuits[i])))
 		}
 
-		buf.WriteString(fmt.Sprintf("Part 1: %d\n", multiplyTop3))
 		fmt.Print(buf.String())
-		return
+		time.Sleep(500 * time.Millisecond)
 	}
 
-	fmt.Println("Part 1:", multiplyTop3)
+	return aoc.Int(multiplyTop3), nil
 }
 
-type JunctionBoxes []Vector
+type JunctionBoxes []point.Point3
 
-func (junctionBoxes JunctionBoxes) buildPairs() []Pair {
-	var pairs []Pair
+// buildPairs joins every two junction boxes, closest first. The weight is the
+// square of the distance, which sorts them just like the distance would.
+func (junctionBoxes JunctionBoxes) buildPairs() []disjoint.Edge {
+	var pairs []disjoint.Edge
 	for r := 0; r < len(junctionBoxes)-1; r++ {
 		for c := r + 1; c < len(junctionBoxes); c++ {
-			distSq := junctionBoxes[r].DistanceSq(junctionBoxes[c])
-			pairs = append(pairs, Pair{
-				U:        junctionBoxes[r],
-				V:        junctionBoxes[c],
-				Distance: math.Sqrt(distSq),
+			pairs = append(pairs, disjoint.Edge{
+				U:      r,
+				V:      c,
+				Weight: junctionBoxes[r].DistanceSq(junctionBoxes[c]),
 			})
 		}
 	}
 
-	sort.Slice(pairs, func(a, b int) bool { return pairs[a].Distance < pairs[b].Distance })
+	sort.Slice(pairs, func(a, b int) bool { return pairs[a].Weight < pairs[b].Weight })
 	return pairs
 }
 
-func (junctionBoxes JunctionBoxes) buildIndexMap() map[Vector]int {
-	jbIdxMap := make(map[Vector]int)
-	for i, jb := range junctionBoxes {
-		jbIdxMap[jb] = i
-	}
-	return jbIdxMap
-}
-
-func renderCircuits(circuits [][]Vector, depth int) {
+func renderCircuits(circuits [][]point.Point3, depth int) {
 	cellRenderer := func(ctx utils.CellRenderContext) string {
 		if ctx.Cell == "#" {
 			return utils.BgOrange + utils.White + ctx.Cell + utils.Reset
@@ -192,83 +124,34 @@ func renderCircuits(circuits [][]Vector, depth int) {
 	for i := range depth {
 		circuit := circuits[i]
 		gridSize := 42
-		grid := make([][]string, gridSize)
-		for r := range grid {
-			grid[r] = make([]string, gridSize)
-			for c := range grid[r] {
-				grid[r][c] = "."
-			}
-		}
+		display := grid.New(gridSize, gridSize, ".")
 
 		for _, jb := range circuit {
-			x := int(jb.X) % gridSize
-			y := int(jb.Y) % gridSize
-			grid[y][x] = "#"
-			utils.RenderGrid(grid, y, x, nil, cellRenderer)
+			x := jb.X % gridSize
+			y := jb.Y % gridSize
+			display.Set(display.Index(y, x), "#")
+			utils.RenderGrid(display, display.Index(y, x), nil, cellRenderer)
 		}
 		time.Sleep(55 * time.Millisecond)
 	}
 }
 
-func part2(junctionBoxes JunctionBoxes, withVisual bool) {
-
-	pairs := junctionBoxes.buildPairs()
-	jbIdxMap := junctionBoxes.buildIndexMap()
-
-	manager := NewCircuitsManager(len(junctionBoxes))
-
-	multiplyLastConnectionX := 1
-	for _, pair := range pairs {
-		u := jbIdxMap[pair.U]
-		v := jbIdxMap[pair.V]
-
-		manager.Connect(u, v)
-
-		if manager.counts[manager.Search(u)] == len(junctionBoxes) {
-			multiplyLastConnectionX = int(pair.U.X) * int(pair.V.X)
-			break
-		}
-	}
-
-	circuitsMap := make(map[int][]Vector)
-
-	for i := range junctionBoxes {
-		cId := manager.Search(i)
-		circuitsMap[cId] = append(circuitsMap[cId], junctionBoxes[i])
-	}
-
-	var maxCircuitPath []Vector
-	for _, circuit := range circuitsMap {
-		if len(circuit) > len(maxCircuitPath) {
-			maxCircuitPath = circuit
-		}
-	}
-
+func part2(playground Playground) (aoc.Answer, error) {
+	junctionBoxes := playground.junctionBoxes
+	withVisual := aoc.Visual()
 	if withVisual {
-		renderCircuits([][]Vector{maxCircuitPath}, 1)
+		defer fmt.Print(utils.ShowCursor)
 	}
 
-	fmt.Printf("Part 2: %d\n", multiplyLastConnectionX)
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
+	circuitSet := disjoint.New(len(junctionBoxes))
+	last, ok := circuitSet.Connect(junctionBoxes.buildPairs())
+	if !ok {
+		return aoc.Answer{}, errors.New("the junction boxes never make a single circuit")
 	}
 
-	withVisual := os.Getenv("AOC_VISUAL") == "1"
-
-	defer fmt.Print(utils.ShowCursor)
-
-	formattedData := formatData(data)
-	part1(formattedData, withVisual)
-
 	if withVisual {
-		time.Sleep(500 * time.Millisecond)
+		renderCircuits([][]point.Point3{junctionBoxes}, 1)
 	}
 
-	fmt.Print(utils.ShowCursor)
-	part2(formattedData, withVisual)
+	return aoc.Int(junctionBoxes[last.U].X * junctionBoxes[last.V].X), nil
 }
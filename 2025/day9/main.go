@@ -1,14 +1,13 @@
-package main
+package day9
 
 import (
+	"aoc"
+	"aoc/grid"
+	"aoc/point"
 	"aoc2025/utils"
-	"fmt"
 	"math"
-	"os"
-	"path/filepath"
 	"runtime"
 	"sort"
-	"strings"
 	"sync"
 	"time"
 )
@@ -18,64 +17,55 @@ const (
 	viewportHeight = 9 * 10
 )
 
-func readData() ([]string, error) {
-	data, err := os.ReadFile(filepath.Join(".", "data.txt"))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(string(data), "\n"), nil
+func init() {
+	aoc.Register(2025, 9, aoc.Solution[Coords]{
+		Parse: formatData,
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func formatData(rows []string) Coords {
+func formatData(in aoc.Input) (Coords, error) {
 	var coords Coords
-	for _, row := range rows {
-		var x, y int
-		fmt.Sscanf(row, "%d,%d", &x, &y)
-		coords = append(coords, Coord{R: x, C: y})
+	for _, row := range in.Rows() {
+		tile, err := point.Parse(row)
+		if err != nil {
+			return nil, err
+		}
+		coords = append(coords, tile)
 	}
-	return coords
-}
-
-type Coord struct {
-	R, C int
-}
-
-func (a Coord) DistanceSq(b Coord) float64 {
-	dx := float64(a.R - b.R)
-	dy := float64(a.C - b.C)
-	return dx*dx + dy*dy
-}
-
-func (a Coord) Distance(b Coord) float64 {
-	return math.Sqrt(a.DistanceSq(b))
+	if len(coords) < 2 {
+		return nil, in.Errorf("expected at least two red tiles, got %d", len(coords))
+	}
+	return coords, nil
 }
 
-type Coords []Coord
+type Coords []point.Point
 
 type Pair struct {
-	U, V  Coord
+	U, V  point.Point
 	Area  int
 	Valid bool
 }
 
-func calculateArea(u, v Coord) int {
-	minR := int(math.Min(float64(u.R), float64(v.R)))
-	maxR := int(math.Max(float64(u.R), float64(v.R)))
-	minC := int(math.Min(float64(u.C), float64(v.C)))
-	maxC := int(math.Max(float64(u.C), float64(v.C)))
+func calculateArea(u, v point.Point) int {
+	minR := min(u.X, v.X)
+	maxR := max(u.X, v.X)
+	minC := min(u.Y, v.Y)
+	maxC := max(u.Y, v.Y)
 
 	width := maxR - minR + 1
 	height := maxC - minC + 1
 	return height * width
 }
 
-func (p *Pair) IsWithinRectangle(coord Coord) bool {
-	minR := int(math.Min(float64(p.U.R), float64(p.V.R)))
-	maxR := int(math.Max(float64(p.U.R), float64(p.V.R)))
-	minC := int(math.Min(float64(p.U.C), float64(p.V.C)))
-	maxC := int(math.Max(float64(p.U.C), float64(p.V.C)))
+func (p *Pair) IsWithinRectangle(coord point.Point) bool {
+	minR := min(p.U.X, p.V.X)
+	maxR := max(p.U.X, p.V.X)
+	minC := min(p.U.Y, p.V.Y)
+	maxC := max(p.U.Y, p.V.Y)
 
-	return coord.R >= minR && coord.R <= maxR && coord.C >= minC && coord.C <= maxC
+	return coord.X >= minR && coord.X <= maxR && coord.Y >= minC && coord.Y <= maxC
 }
 
 func normaliseAndScale(coords Coords, width, height int) Coords {
@@ -83,13 +73,13 @@ func normaliseAndScale(coords Coords, width, height int) Coords {
 		return coords
 	}
 
-	minR, maxR := coords[0].R, coords[0].R
-	minC, maxC := coords[0].C, coords[0].C
+	minR, maxR := coords[0].X, coords[0].X
+	minC, maxC := coords[0].Y, coords[0].Y
 	for _, coord := range coords[1:] {
-		minR = min(minR, coord.R)
-		maxR = max(maxR, coord.R)
-		minC = min(minC, coord.C)
-		maxC = max(maxC, coord.C)
+		minR = min(minR, coord.X)
+		maxR = max(maxR, coord.X)
+		minC = min(minC, coord.Y)
+		maxC = max(maxC, coord.Y)
 	}
 
 	rangeR := maxR - minR
@@ -106,8 +96,8 @@ func normaliseAndScale(coords Coords, width, height int) Coords {
 	scaleC := float64(height-1) / float64(rangeC*2)
 
 	for i, coord := range coords {
-		normR := coord.R - minR
-		normC := coord.C - minC
+		normR := coord.X - minR
+		normC := coord.Y - minC
 
 		scaledR := int(math.Round(float64(normR) * scaleR))
 		scaledC := int(math.Round(float64(normC) * scaleC))
@@ -123,40 +113,34 @@ func normaliseAndScale(coords Coords, width, height int) Coords {
 			scaledC = height - 1
 		}
 
-		scaled[i] = Coord{R: scaledR, C: scaledC}
+		scaled[i] = point.Pt(scaledR, scaledC)
 	}
 
 	return scaled
 }
 
-func (coords *Coords) BuildGrid() [][]string {
+func (coords *Coords) BuildGrid() *grid.Grid[string] {
 	var maxX, maxY int
 
 	for _, coord := range *coords {
-		if coord.R > maxX {
-			maxX = coord.R
+		if coord.X > maxX {
+			maxX = coord.X
 		}
-		if coord.C > maxY {
-			maxY = coord.C
-		}
-	}
-
-	grid := make([][]string, maxY+1)
-	for r := range grid {
-		grid[r] = make([]string, maxX+1)
-		for c := range grid[r] {
-			grid[r][c] = "."
+		if coord.Y > maxY {
+			maxY = coord.Y
 		}
 	}
 
+	display := grid.New(maxX+1, maxY+1, ".")
 	for _, coord := range *coords {
-		grid[coord.C][coord.R] = "#"
+		display.Set(display.Index(coord.Y, coord.X), "#")
 	}
 
-	return grid
+	return display
 }
 
-func part1(data Coords, withVisuals bool) {
+func part1(data Coords) (aoc.Answer, error) {
+	withVisuals := aoc.Visual()
 	coords := make(Coords, len(data))
 	copy(coords, data)
 
@@ -182,24 +166,16 @@ func part1(data Coords, withVisuals bool) {
 
 			dU := displayCoords[r]
 			dV := displayCoords[c]
-			minR := int(math.Min(float64(dU.R), float64(dV.R)))
-			maxR := int(math.Max(float64(dU.R), float64(dV.R)))
-			minC := int(math.Min(float64(dU.C), float64(dV.C)))
-			maxC := int(math.Max(float64(dU.C), float64(dV.C)))
-
-			grid := displayCoords.BuildGrid()
-			for gr := range grid {
-				for gc := range grid[gr] {
-					if gc >= minR && gc <= maxR && gr >= minC && gr <= maxC {
-						if grid[gr][gc] == "." {
-							grid[gr][gc] = "O"
-						}
-					}
-				}
-			}
-			grid[dU.C][dU.R] = "U"
-			grid[dV.C][dV.R] = "V"
-			utils.RenderGrid(grid, r, c, nil, cellRenderer)
+			minR := min(dU.X, dV.X)
+			maxR := max(dU.X, dV.X)
+			minC := min(dU.Y, dV.Y)
+			maxC := max(dU.Y, dV.Y)
+
+			display := displayCoords.BuildGrid()
+			fillRectangle(display, minR, maxR, minC, maxC, "O")
+			display.Set(display.Index(dU.Y, dU.X), "U")
+			display.Set(display.Index(dV.Y, dV.X), "V")
+			utils.RenderGrid(display, -1, nil, cellRenderer)
 			time.Sleep(1 * time.Millisecond)
 		}
 	}
@@ -207,7 +183,7 @@ func part1(data Coords, withVisuals bool) {
 	sort.Slice(pairs, func(a, b int) bool { return pairs[a].Area > pairs[b].Area })
 
 	largestRectangle := pairs[0]
-	fmt.Println("Part 1:", largestRectangle.Area)
+	return aoc.Int(largestRectangle.Area), nil
 }
 
 var cellRenderer = func(ctx utils.CellRenderContext) string {
@@ -226,47 +202,48 @@ var cellRenderer = func(ctx utils.CellRenderContext) string {
 	return utils.BgBlack + utils.White + ctx.Cell + utils.Reset
 }
 
-func part2(data Coords, withVisuals bool) {
-	redTiles := map[Coord]bool{}
-	redTilesX := map[int][]Coord{}
-	redTilesY := map[int][]Coord{}
+func part2(data Coords) (aoc.Answer, error) {
+	withVisuals := aoc.Visual()
+	redTiles := map[point.Point]bool{}
+	redTilesX := map[int][]point.Point{}
+	redTilesY := map[int][]point.Point{}
 
 	for _, coord := range data {
 		redTiles[coord] = true
-		redTilesX[coord.R] = append(redTilesX[coord.R], coord)
-		redTilesY[coord.C] = append(redTilesY[coord.C], coord)
+		redTilesX[coord.X] = append(redTilesX[coord.X], coord)
+		redTilesY[coord.Y] = append(redTilesY[coord.Y], coord)
 	}
 
-	markedTiles := map[Coord]bool{}
-	markedTilesY := map[int][]Coord{}
+	markedTiles := map[point.Point]bool{}
+	markedTilesY := map[int][]point.Point{}
 
 	for rt := range redTiles {
-		for _, tile := range redTilesX[rt.R] {
+		for _, tile := range redTilesX[rt.X] {
 			if rt == tile {
 				continue
 			}
-			minY := min(rt.C, tile.C)
-			maxY := max(rt.C, tile.C)
+			minY := min(rt.Y, tile.Y)
+			maxY := max(rt.Y, tile.Y)
 
 			for c := minY; c <= maxY; c++ {
-				cell := Coord{R: rt.R, C: c}
+				cell := point.Pt(rt.X, c)
 				markedTiles[cell] = true
 				markedTilesY[c] = append(markedTilesY[c], cell)
 			}
 		}
 
-		for _, tile := range redTilesY[rt.C] {
+		for _, tile := range redTilesY[rt.Y] {
 			if rt == tile {
 				continue
 			}
 
-			minX := min(rt.R, tile.R)
-			maxX := max(rt.R, tile.R)
+			minX := min(rt.X, tile.X)
+			maxX := max(rt.X, tile.X)
 
 			for c := minX; c <= maxX; c++ {
-				cell := Coord{R: c, C: rt.C}
+				cell := point.Pt(c, rt.Y)
 				markedTiles[cell] = true
-				markedTilesY[rt.C] = append(markedTilesY[rt.C], cell)
+				markedTilesY[rt.Y] = append(markedTilesY[rt.Y], cell)
 			}
 		}
 	}
@@ -282,20 +259,20 @@ func part2(data Coords, withVisuals bool) {
 		maxX := -1
 
 		for _, coord := range coords {
-			minX = min(minX, coord.R)
-			maxX = max(maxX, coord.R)
+			minX = min(minX, coord.X)
+			maxX = max(maxX, coord.X)
 		}
 
 		segments[c] = Segment{Min: minX, Max: maxX}
 	}
 
 	displayCoords := normaliseAndScale(data, viewportWidth, viewportHeight)
-	coordToDisplay := make(map[Coord]Coord)
+	coordToDisplay := make(map[point.Point]point.Point)
 	for i, coord := range data {
 		coordToDisplay[coord] = displayCoords[i]
 	}
 
-	redTilesList := make([]Coord, 0, len(redTiles))
+	redTilesList := make([]point.Point, 0, len(redTiles))
 	for c := range redTiles {
 		redTilesList = append(redTilesList, c)
 	}
@@ -304,7 +281,7 @@ func part2(data Coords, withVisuals bool) {
 		mu                 sync.Mutex
 		renderMu           sync.Mutex
 		largestRectangle   int
-		largestU, largestV Coord
+		largestU, largestV point.Point
 	)
 
 	numWorkers := runtime.NumCPU()
@@ -320,7 +297,7 @@ func part2(data Coords, withVisuals bool) {
 		}
 
 		wg.Add(1)
-		go func(chunk []Coord) {
+		go func(chunk []point.Point) {
 			defer wg.Done()
 
 			for _, c1 := range chunk {
@@ -359,21 +336,7 @@ func part2(data Coords, withVisuals bool) {
 
 	wg.Wait()
 
-	fmt.Println("Part 2:", largestRectangle)
-}
-
-func main() {
-	data, err := readData()
-	if err != nil {
-		fmt.Println("Get rekt:", err)
-		return
-	}
-
-	withVisual := os.Getenv("AOC_VISUAL") == "1"
-
-	formattedData := formatData(data)
-	part1(formattedData, withVisual)
-	part2(formattedData, withVisual)
+	return aoc.Int(largestRectangle), nil
 }
 
 func min(a, b int) int {
@@ -394,11 +357,11 @@ type Segment struct {
 	Min, Max int
 }
 
-func checkTiles(u, v Coord, segments map[int]Segment) bool {
-	minX := min(u.R, v.R)
-	maxX := max(u.R, v.R)
-	minY := min(u.C, v.C)
-	maxY := max(u.C, v.C)
+func checkTiles(u, v point.Point, segments map[int]Segment) bool {
+	minX := min(u.X, v.X)
+	maxX := max(u.X, v.X)
+	minY := min(u.Y, v.Y)
+	maxY := max(u.Y, v.Y)
 
 	for y := minY; y <= maxY; y++ {
 		area := segments[y]
@@ -411,7 +374,7 @@ func checkTiles(u, v Coord, segments map[int]Segment) bool {
 	return true
 }
 
-func renderRectangleVisuals(displayCoords Coords, data Coords, c1 Coord, c2 Coord, largestRectangle int, coordToDisplay map[Coord]Coord, largestU Coord, largestV Coord) {
+func renderRectangleVisuals(displayCoords Coords, data Coords, c1 point.Point, c2 point.Point, largestRectangle int, coordToDisplay map[point.Point]point.Point, largestU point.Point, largestV point.Point) {
 	dU := displayCoords[0]
 	dV := displayCoords[0]
 	for i, coord := range data {
@@ -423,44 +386,39 @@ func renderRectangleVisuals(displayCoords Coords, data Coords, c1 Coord, c2 Coor
 		}
 	}
 
-	minR := min(dU.R, dV.R)
-	maxR := max(dU.R, dV.R)
-	minC := min(dU.C, dV.C)
-	maxC := max(dU.C, dV.C)
-	grid := displayCoords.BuildGrid()
+	minR := min(dU.X, dV.X)
+	maxR := max(dU.X, dV.X)
+	minC := min(dU.Y, dV.Y)
+	maxC := max(dU.Y, dV.Y)
+	display := displayCoords.BuildGrid()
 
 	var largestMinR, largestMaxR, largestMinC, largestMaxC int
 	if largestRectangle > 0 {
 		lU := coordToDisplay[largestU]
 		lV := coordToDisplay[largestV]
-		largestMinR = min(lU.R, lV.R)
-		largestMaxR = max(lU.R, lV.R)
-		largestMinC = min(lU.C, lV.C)
-		largestMaxC = max(lU.C, lV.C)
-
-		for gr := range grid {
-			for gc := range grid[gr] {
-				if gc >= largestMinR && gc <= largestMaxR && gr >= largestMinC && gr <= largestMaxC {
-					if grid[gr][gc] == "." {
-						grid[gr][gc] = "0"
-					}
-				}
-			}
-		}
-	}
+		largestMinR = min(lU.X, lV.X)
+		largestMaxR = max(lU.X, lV.X)
+		largestMinC = min(lU.Y, lV.Y)
+		largestMaxC = max(lU.Y, lV.Y)
 
-	for gr := range grid {
-		for gc := range grid[gr] {
-			if gc >= minR && gc <= maxR && gr >= minC && gr <= maxC {
-				if grid[gr][gc] == "." {
-					grid[gr][gc] = "O"
-				}
-			}
-		}
+		fillRectangle(display, largestMinR, largestMaxR, largestMinC, largestMaxC, "0")
 	}
-	grid[dU.C][dU.R] = "U"
-	grid[dV.C][dV.R] = "V"
 
-	utils.RenderGrid(grid, -1, -1, nil, cellRenderer)
+	fillRectangle(display, minR, maxR, minC, maxC, "O")
+	display.Set(display.Index(dU.Y, dU.X), "U")
+	display.Set(display.Index(dV.Y, dV.X), "V")
+
+	utils.RenderGrid(display, -1, nil, cellRenderer)
 	time.Sleep(1 * time.Millisecond)
 }
+
+// fillRectangle marks the empty cells of the rectangle between columns minR
+// and maxR and rows minC and maxC, the display's coordinates being swapped.
+func fillRectangle(display *grid.Grid[string], minR, maxR, minC, maxC int, mark string) {
+	for i, cell := range display.Cells {
+		gr, gc := display.Pos(i)
+		if cell == "." && gc >= minR && gc <= maxR && gr >= minC && gr <= maxC {
+			display.Set(i, mark)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"aoc/grid"
 	"fmt"
 	"strings"
 	"time"
@@ -37,30 +38,22 @@ type CellRenderContext struct {
 
 type CellRenderer func(ctx CellRenderContext) string
 
-func RenderGrid(grid [][]string, activeRow, activeCol int, activePath map[string]bool, cellRenderer CellRenderer) {
+// RenderGrid draws the grid over the previous frame, redrawing only the lines
+// that changed. active is the index of the highlighted cell, -1 for none.
+func RenderGrid(g *grid.Grid[string], active int, activePath map[int]bool, cellRenderer CellRenderer) {
 	if cellRenderer == nil {
 		cellRenderer = func(ctx CellRenderContext) string {
 			return ctx.Cell
 		}
 	}
 
-	newLines := make([]string, len(grid))
-	for rowIdx, row := range grid {
-		var sb strings.Builder
-		for colIdx, cell := range row {
-			isActive := rowIdx == activeRow && colIdx == activeCol
-			key := fmt.Sprintf("%d_%d", rowIdx, colIdx)
-			isInActivePath := activePath != nil && activePath[key]
-
-			ctx := CellRenderContext{
-				Cell:           cell,
-				IsActive:       isActive,
-				IsInActivePath: isInActivePath,
-			}
-			sb.WriteString(cellRenderer(ctx))
-		}
-		newLines[rowIdx] = sb.String()
-	}
+	newLines := strings.Split(strings.TrimSuffix(g.Render(func(i int, cell string) string {
+		return cellRenderer(CellRenderContext{
+			Cell:           cell,
+			IsActive:       i == active,
+			IsInActivePath: activePath[i],
+		})
+	}), "\n"), "\n")
 
 	if prevLines == nil || len(prevLines) != len(newLines) {
 		var buf strings.Builder
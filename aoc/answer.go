@@ -0,0 +1,62 @@
+package aoc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Answer is what a part solves to: an int, a big int or a string such as the
+// comma separated output of 2024 day 17.
+type Answer struct {
+	value any
+}
+
+// Int wraps an integer answer.
+func Int[N ~int | ~int64](n N) Answer {
+	return Answer{value: int64(n)}
+}
+
+// BigInt wraps an answer that does not fit in 64 bits.
+func BigInt(n *big.Int) Answer {
+	return Answer{value: new(big.Int).Set(n)}
+}
+
+// String wraps a textual answer.
+func String(s string) Answer {
+	return Answer{value: s}
+}
+
+// IsZero reports whether the answer was never set.
+func (a Answer) IsZero() bool {
+	return a.value == nil
+}
+
+// Int64 returns the answer as an integer, if it is one that fits.
+func (a Answer) Int64() (int64, bool) {
+	switch v := a.value.(type) {
+	case int64:
+		return v, true
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64(), true
+		}
+	}
+	return 0, false
+}
+
+// Equal compares answers by their printed form, so an int and a big int of the
+// same value are equal.
+func (a Answer) Equal(b Answer) bool {
+	return !a.IsZero() && !b.IsZero() && a.String() == b.String()
+}
+
+func (a Answer) String() string {
+	switch v := a.value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,189 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Input is the raw puzzle input handed to a day's Parse step, along with the
+// named parameters that differ between an example and the real puzzle, such
+// as the size of a grid.
+type Input struct {
+	Name   string
+	Text   string
+	Params map[string]string
+}
+
+// Lines splits the normalised input on newlines, like every readData used
+// to, without the empty rows a trailing newline or "\r\n" endings would leave.
+func (in Input) Lines() []string {
+	return strings.Split(Normalise(in.Text), "\n")
+}
+
+// Int returns the named integer parameter, or fallback when it is not set.
+func (in Input) Int(name string, fallback int) (int, error) {
+	value, ok := in.Params[name]
+	if !ok {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: param %s: %w", in.Name, name, err)
+	}
+	return n, nil
+}
+
+// Part solves one half of a day. It returns the answer rather than printing
+// it, so the runner and other tooling can display, compare and store it.
+type Part[T any] func(data T) (Answer, error)
+
+// Solution wires a day's parse step to its parts. Parse runs once per part,
+// so parts are free to mutate the data they are given.
+type Solution[T any] struct {
+	Parse func(in Input) (T, error)
+	Part1 Part[T]
+	Part2 Part[T]
+}
+
+// Day is a registered solution with its data type erased, so days from every
+// year can live in the same registry. Variant is empty for a day's main
+// solution and names the alternative otherwise, like "parallel".
+type Day struct {
+	Year    int
+	Day     int
+	Variant string
+	parse   func(in Input) (any, error)
+	parts   [2]func(data any) (Answer, error)
+}
+
+type dayKey struct {
+	year, day int
+	variant   string
+}
+
+var registry = make(map[dayKey]*Day)
+
+// Register adds a day to the registry, usually from the day's init function.
+func Register[T any](year, day int, s Solution[T]) {
+	register(year, day, "", s)
+}
+
+// RegisterVariant adds an alternative solution of a day, such as the parallel
+// take on 2024 day 6, so the two can be checked and benchmarked side by side.
+// A variant may solve only the part it does differently.
+func RegisterVariant[T any](year, day int, variant string, s Solution[T]) {
+	if variant == "" {
+		panic(fmt.Sprintf("aoc: %d day %d variant needs a name", year, day))
+	}
+	register(year, day, variant, s)
+}
+
+func register[T any](year, day int, variant string, s Solution[T]) {
+	key := dayKey{year, day, variant}
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("aoc: %s registered twice", key))
+	}
+
+	d := &Day{
+		Year:    year,
+		Day:     day,
+		Variant: variant,
+		parse: func(in Input) (any, error) {
+			return s.Parse(in)
+		},
+	}
+	for i, part := range []Part[T]{s.Part1, s.Part2} {
+		if part == nil {
+			continue
+		}
+		d.parts[i] = func(data any) (Answer, error) { return part(data.(T)) }
+	}
+	registry[key] = d
+}
+
+// Lookup returns the registered day, if any.
+func Lookup(year, day int) (*Day, bool) {
+	d, ok := registry[dayKey{year, day, ""}]
+	return d, ok
+}
+
+// Variants lists the alternative solutions of a day, sorted by name.
+func Variants(year, day int) []*Day {
+	var variants []*Day
+	for key, d := range registry {
+		if key.year == year && key.day == day && key.variant != "" {
+			variants = append(variants, d)
+		}
+	}
+	sort.Slice(variants, func(a, b int) bool { return variants[a].Variant < variants[b].Variant })
+	return variants
+}
+
+// Years lists every year with at least one registered day.
+func Years() []int {
+	seen := make(map[int]bool)
+	var years []int
+	for key := range registry {
+		if !seen[key.year] {
+			seen[key.year] = true
+			years = append(years, key.year)
+		}
+	}
+	sort.Ints(years)
+	return years
+}
+
+// Days lists the registered days of a year in order, without their variants.
+func Days(year int) []*Day {
+	var days []*Day
+	for key, d := range registry {
+		if key.year == year && key.variant == "" {
+			days = append(days, d)
+		}
+	}
+	sort.Slice(days, func(a, b int) bool { return days[a].Day < days[b].Day })
+	return days
+}
+
+func (k dayKey) String() string {
+	if k.variant != "" {
+		return fmt.Sprintf("%d day %d (%s)", k.year, k.day, k.variant)
+	}
+	return fmt.Sprintf("%d day %d", k.year, k.day)
+}
+
+func (d *Day) String() string {
+	return dayKey{d.Year, d.Day, d.Variant}.String()
+}
+
+// Dir is the day's directory relative to the repository root.
+func (d *Day) Dir() string {
+	if d.Year == 2024 {
+		return fmt.Sprintf("%d/%d", d.Year, d.Day)
+	}
+	return fmt.Sprintf("%d/day%d", d.Year, d.Day)
+}
+
+// Parse turns the input into the day's data.
+func (d *Day) Parse(in Input) (any, error) {
+	return d.parse(in)
+}
+
+// HasPart reports whether the day solves the given part (1 or 2).
+func (d *Day) HasPart(part int) bool {
+	return part >= 1 && part <= 2 && d.parts[part-1] != nil
+}
+
+// Solve runs a single part against data returned by Parse.
+func (d *Day) Solve(part int, data any) (Answer, error) {
+	return d.parts[part-1](data)
+}
+
+// Visual reports whether days should render their visualisations, as toggled
+// by the runner's -visual flag or AOC_VISUAL=1.
+func Visual() bool {
+	return os.Getenv("AOC_VISUAL") == "1"
+}
@@ -0,0 +1,131 @@
+// Package disjoint is a union-find over the elements 0 to n-1: it merges
+// them into components and tells which component each one is in, and grows
+// minimum spanning trees with it.
+package disjoint
+
+import "sort"
+
+// Set splits its elements into disjoint components, each element starting
+// alone in its own.
+type Set struct {
+	parent []int
+	size   []int
+	count  int
+}
+
+// New is a set of n elements in n components.
+func New(n int) *Set {
+	s := &Set{parent: make([]int, n), size: make([]int, n), count: n}
+	for i := range n {
+		s.parent[i] = i
+		s.size[i] = 1
+	}
+	return s
+}
+
+// Find is the element standing for x's component, the same for every element
+// in it until the component is merged again.
+func (s *Set) Find(x int) int {
+	root := x
+	for s.parent[root] != root {
+		root = s.parent[root]
+	}
+	// point the whole way up at the root so the next find is quick
+	for s.parent[x] != root {
+		s.parent[x], x = root, s.parent[x]
+	}
+	return root
+}
+
+// Union merges the components of a and b, the smaller into the larger. It is
+// false when they already were the same component.
+func (s *Set) Union(a, b int) bool {
+	a, b = s.Find(a), s.Find(b)
+	if a == b {
+		return false
+	}
+	if s.size[a] < s.size[b] {
+		a, b = b, a
+	}
+	s.parent[b] = a
+	s.size[a] += s.size[b]
+	s.count--
+	return true
+}
+
+// Same tells whether a and b are in the same component.
+func (s *Set) Same(a, b int) bool {
+	return s.Find(a) == s.Find(b)
+}
+
+// Size is how many elements x's component has.
+func (s *Set) Size(x int) int {
+	return s.size[s.Find(x)]
+}
+
+// Count is how many components there are.
+func (s *Set) Count() int {
+	return s.count
+}
+
+// Components lists the elements of every component, in the order of their
+// first elements.
+func (s *Set) Components() [][]int {
+	index := make(map[int]int)
+	var components [][]int
+	for x := range s.parent {
+		root := s.Find(x)
+		i, ok := index[root]
+		if !ok {
+			i = len(components)
+			index[root] = i
+			components = append(components, nil)
+		}
+		components[i] = append(components[i], x)
+	}
+	return components
+}
+
+// Edge joins elements U and V at a cost of Weight.
+type Edge struct {
+	U, V   int
+	Weight int
+}
+
+// Connect merges the components of edges, in the order given, until every
+// element is in one. It returns the edge that made it one, or false when the
+// edges run out first.
+func (s *Set) Connect(edges []Edge) (Edge, bool) {
+	if s.count <= 1 {
+		return Edge{}, false
+	}
+	for _, e := range edges {
+		if s.Union(e.U, e.V) && s.count == 1 {
+			return e, true
+		}
+	}
+	return Edge{}, false
+}
+
+// Kruskal is a minimum spanning forest of the n elements, the lightest edges
+// joining every element it can without a cycle, and its total weight. Edges
+// of the same weight are taken in the order given. When the elements end up
+// in one component, the last edge is the one that completed it.
+func Kruskal(n int, edges []Edge) ([]Edge, int) {
+	sorted := append([]Edge(nil), edges...)
+	sort.SliceStable(sorted, func(a, b int) bool { return sorted[a].Weight < sorted[b].Weight })
+
+	s := New(n)
+	var tree []Edge
+	weight := 0
+	for _, e := range sorted {
+		if s.Union(e.U, e.V) {
+			tree = append(tree, e)
+			weight += e.Weight
+			if s.count == 1 {
+				break
+			}
+		}
+	}
+	return tree, weight
+}
@@ -0,0 +1,143 @@
+package disjoint
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	s := New(8)
+	tests := []struct {
+		a, b   int
+		merged bool
+		size   int
+		count  int
+	}{
+		{0, 1, true, 2, 7},
+		{2, 3, true, 2, 6},
+		{1, 0, false, 2, 6},
+		{3, 1, true, 4, 5},
+		{4, 4, false, 1, 5},
+		{5, 0, true, 5, 4},
+		{2, 5, false, 5, 4},
+		{6, 7, true, 2, 3},
+	}
+	for _, tt := range tests {
+		if merged := s.Union(tt.a, tt.b); merged != tt.merged {
+			t.Errorf("Union(%d, %d) = %v, want %v", tt.a, tt.b, merged, tt.merged)
+		}
+		if !s.Same(tt.a, tt.b) {
+			t.Errorf("%d and %d apart after Union", tt.a, tt.b)
+		}
+		if s.Size(tt.a) != tt.size || s.Size(tt.b) != tt.size || s.Count() != tt.count {
+			t.Errorf("after Union(%d, %d): sizes %d, %d and %d components, want %d and %d",
+				tt.a, tt.b, s.Size(tt.a), s.Size(tt.b), s.Count(), tt.size, tt.count)
+		}
+	}
+
+	for _, pair := range [][2]int{{0, 4}, {4, 6}, {7, 3}} {
+		if s.Same(pair[0], pair[1]) {
+			t.Errorf("%d and %d in the same component", pair[0], pair[1])
+		}
+	}
+	root := s.Find(0)
+	for _, x := range []int{1, 2, 3, 5} {
+		if s.Find(x) != root {
+			t.Errorf("Find(%d) = %d, want %d like the rest of its component", x, s.Find(x), root)
+		}
+	}
+
+	want := [][]int{{0, 1, 2, 3, 5}, {4}, {6, 7}}
+	if got := s.Components(); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Components = %v, want %v", got, want)
+	}
+}
+
+func TestUnionBySize(t *testing.T) {
+	// whichever way round the union is asked for, the larger component's
+	// root stays the root
+	for _, swap := range []bool{false, true} {
+		s := New(4)
+		s.Union(0, 1)
+		s.Union(0, 2)
+		big := s.Find(0)
+		a, b := 3, 0
+		if swap {
+			a, b = b, a
+		}
+		s.Union(a, b)
+		if got := s.Find(3); got != big {
+			t.Errorf("swap %v: root %d, want the larger component's %d", swap, got, big)
+		}
+	}
+
+	// a long chain of unions stays shallow
+	s := New(1 << 12)
+	for i := 1; i < 1<<12; i++ {
+		s.Union(i, i-1)
+	}
+	for x := range s.parent {
+		depth := 0
+		for y := x; s.parent[y] != y; y = s.parent[y] {
+			depth++
+		}
+		if depth > 12 {
+			t.Fatalf("%d is %d deep, more than log2 of the elements", x, depth)
+		}
+	}
+}
+
+func TestConnect(t *testing.T) {
+	edges := []Edge{{0, 1, 0}, {1, 0, 0}, {2, 3, 0}, {1, 2, 0}, {3, 0, 0}}
+	if e, ok := New(4).Connect(edges); !ok || e != edges[3] {
+		t.Errorf("Connect = %v, %v, want %v", e, ok, edges[3])
+	}
+	if e, ok := New(5).Connect(edges); ok {
+		t.Errorf("Connect joined 5 elements with %v", e)
+	}
+	if _, ok := New(1).Connect(edges); ok {
+		t.Error("Connect joined a single element")
+	}
+}
+
+func TestKruskal(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		edges  []Edge
+		tree   []Edge
+		weight int
+	}{
+		{"nothing", 0, nil, nil, 0},
+		{"no edges", 3, nil, nil, 0},
+		{
+			"square with a diagonal",
+			4,
+			[]Edge{{0, 1, 4}, {1, 2, 1}, {2, 3, 3}, {3, 0, 2}, {0, 2, 5}},
+			[]Edge{{1, 2, 1}, {3, 0, 2}, {2, 3, 3}},
+			6,
+		},
+		{
+			"ties in the order given",
+			3,
+			[]Edge{{0, 1, 1}, {1, 2, 1}, {0, 2, 1}},
+			[]Edge{{0, 1, 1}, {1, 2, 1}},
+			2,
+		},
+		{
+			"forest",
+			5,
+			[]Edge{{0, 1, 7}, {3, 4, 2}, {1, 0, 1}},
+			[]Edge{{1, 0, 1}, {3, 4, 2}},
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, weight := Kruskal(tt.n, tt.edges)
+			if !slices.Equal(tree, tt.tree) || weight != tt.weight {
+				t.Errorf("Kruskal = %v, %d, want %v, %d", tree, weight, tt.tree, tt.weight)
+			}
+		})
+	}
+}
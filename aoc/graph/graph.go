@@ -0,0 +1,327 @@
+// Package graph is nodes joined by edges, directed or not, and the usual
+// questions about them: the largest clique, an order respecting every edge,
+// the strongly connected components and how many paths lead from one node to
+// another. Nodes and neighbours are kept in the order they were added, so
+// every answer comes out the same on every run.
+package graph
+
+import (
+	"aoc"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Graph is a set of nodes and the edges between them.
+type Graph[N comparable] struct {
+	directed bool
+	nodes    []N
+	out      map[N][]N
+	edges    map[[2]N]bool
+}
+
+// Directed is an empty graph whose edges go one way.
+func Directed[N comparable]() *Graph[N] {
+	return &Graph[N]{directed: true, out: make(map[N][]N), edges: make(map[[2]N]bool)}
+}
+
+// Undirected is an empty graph whose edges go both ways.
+func Undirected[N comparable]() *Graph[N] {
+	return &Graph[N]{out: make(map[N][]N), edges: make(map[[2]N]bool)}
+}
+
+// AddNode adds n, which is fine to do more than once.
+func (g *Graph[N]) AddNode(n N) {
+	if _, ok := g.out[n]; !ok {
+		g.out[n] = nil
+		g.nodes = append(g.nodes, n)
+	}
+}
+
+// AddEdge joins from to to, and to back to from when the graph is undirected.
+// Both nodes are added when they are new, and an edge already there is left
+// as it is.
+func (g *Graph[N]) AddEdge(from, to N) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.link(from, to)
+	if !g.directed {
+		g.link(to, from)
+	}
+}
+
+func (g *Graph[N]) link(from, to N) {
+	if !g.edges[[2]N{from, to}] {
+		g.edges[[2]N{from, to}] = true
+		g.out[from] = append(g.out[from], to)
+	}
+}
+
+// Nodes lists the nodes in the order they were added.
+func (g *Graph[N]) Nodes() []N {
+	return g.nodes
+}
+
+// Neighbours lists the nodes n has an edge to.
+func (g *Graph[N]) Neighbours(n N) []N {
+	return g.out[n]
+}
+
+// Has tells whether there is an edge from one node to the other.
+func (g *Graph[N]) Has(from, to N) bool {
+	return g.edges[[2]N{from, to}]
+}
+
+// ParseEdges reads a graph with an edge a line, its two ends separated by
+// sep, like the "kh-tc" links of 2024 day 23.
+func ParseEdges(rows []aoc.Line, sep string, directed bool) (*Graph[string], error) {
+	g := Undirected[string]()
+	if directed {
+		g = Directed[string]()
+	}
+	for _, row := range rows {
+		ends, err := row.SplitN(sep, 2)
+		if err != nil {
+			return nil, err
+		}
+		for _, end := range ends {
+			if strings.TrimSpace(end.Text) == "" {
+				return nil, end.Errorf("expected a node, got %q", row.Text)
+			}
+		}
+		g.AddEdge(ends[0].Text, ends[1].Text)
+	}
+	return g, nil
+}
+
+// ParseAdjacency reads a directed graph written a node a line, followed by a
+// colon and the nodes its edges go to, like "aaa: bbb ccc". A node can't be
+// listed twice nor have no edges.
+func ParseAdjacency(rows []aoc.Line) (*Graph[string], error) {
+	g := Directed[string]()
+	listed := make(map[string]bool)
+	for _, row := range rows {
+		node, targets, err := row.Cut(":")
+		if err != nil {
+			return nil, err
+		}
+		from := strings.TrimSpace(node.Text)
+		if from == "" {
+			return nil, row.Errorf("expected a node before the colon, got %q", row.Text)
+		}
+		if listed[from] {
+			return nil, node.Errorf("%s is listed twice", from)
+		}
+		listed[from] = true
+
+		fields := targets.Fields()
+		if len(fields) == 0 {
+			return nil, targets.Errorf("expected the nodes %s leads to", from)
+		}
+		g.AddNode(from)
+		for _, to := range fields {
+			g.AddEdge(from, to.Text)
+		}
+	}
+	return g, nil
+}
+
+// MaxClique is a largest set of nodes all joined to each other, found with
+// Bron–Kerbosch, pivoting on the candidate with the most neighbours left. The
+// graph has to be undirected.
+func (g *Graph[N]) MaxClique() []N {
+	var best []N
+	var grow func(clique []N, candidates, excluded []N)
+	grow = func(clique []N, candidates, excluded []N) {
+		if len(candidates) == 0 && len(excluded) == 0 {
+			if len(clique) > len(best) {
+				best = append([]N(nil), clique...)
+			}
+			return
+		}
+		if len(clique)+len(candidates) <= len(best) {
+			return
+		}
+
+		var pivot []N
+		most := -1
+		for _, u := range slices.Concat(candidates, excluded) {
+			if n := len(g.among(u, candidates)); n > most {
+				pivot, most = g.out[u], n
+			}
+		}
+		for _, v := range candidates {
+			if slices.Contains(pivot, v) {
+				continue
+			}
+			grow(append(clique, v), g.among(v, candidates), g.among(v, excluded))
+			candidates = remove(candidates, v)
+			excluded = append(excluded, v)
+		}
+	}
+	grow(nil, append([]N(nil), g.nodes...), nil)
+	return best
+}
+
+// among is the nodes of set n has an edge to.
+func (g *Graph[N]) among(n N, set []N) []N {
+	var kept []N
+	for _, m := range set {
+		if g.Has(n, m) {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// CycleError is a graph that can't be ordered because of the cycle it has.
+type CycleError[N comparable] struct {
+	// Cycle starts and ends with the same node.
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	nodes := make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		nodes[i] = fmt.Sprint(n)
+	}
+	return "graph has a cycle: " + strings.Join(nodes, " -> ")
+}
+
+// TopoSort orders the nodes so every edge goes forwards. A graph with a cycle
+// has no such order, and a *CycleError tells which one.
+func (g *Graph[N]) TopoSort() ([]N, error) {
+	const (
+		unseen = iota
+		open
+		closed
+	)
+	state := make(map[N]int)
+	var stack, order []N
+
+	var visit func(n N) error
+	visit = func(n N) error {
+		switch state[n] {
+		case open:
+			from := len(stack) - 1
+			for stack[from] != n {
+				from--
+			}
+			return &CycleError[N]{Cycle: append(append([]N(nil), stack[from:]...), n)}
+		case closed:
+			return nil
+		}
+		state[n] = open
+		stack = append(stack, n)
+		for _, m := range g.out[n] {
+			if err := visit(m); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = closed
+		order = append(order, n)
+		return nil
+	}
+
+	for _, n := range g.nodes {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// Components is the strongly connected components, the largest sets of
+// nodes that can each reach all the others, found with Tarjan's algorithm.
+// Every component comes after the components it has edges to.
+func (g *Graph[N]) Components() [][]N {
+	index := make(map[N]int)
+	low := make(map[N]int)
+	onStack := make(map[N]bool)
+	var stack []N
+	var components [][]N
+
+	var connect func(n N)
+	connect = func(n N) {
+		index[n] = len(index)
+		low[n] = index[n]
+		stack = append(stack, n)
+		onStack[n] = true
+
+		for _, m := range g.out[n] {
+			if _, seen := index[m]; !seen {
+				connect(m)
+				low[n] = min(low[n], low[m])
+			} else if onStack[m] {
+				low[n] = min(low[n], index[m])
+			}
+		}
+
+		if low[n] == index[n] {
+			var component []N
+			for {
+				m := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[m] = false
+				component = append(component, m)
+				if m == n {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for _, n := range g.nodes {
+		if _, seen := index[n]; !seen {
+			connect(n)
+		}
+	}
+	return components
+}
+
+// CountPaths is how many paths lead from one node to the other, working out
+// the paths from each node once however many ways lead to it. That needs the
+// graph to have no cycles between the two: edges back to a node already on
+// the path are skipped so a cycle can't go round forever, but the count
+// around one isn't exact.
+func (g *Graph[N]) CountPaths(from, to N) int {
+	memo := make(map[N]int)
+	onPath := make(map[N]bool)
+
+	var count func(n N) int
+	count = func(n N) int {
+		if n == to {
+			return 1
+		}
+		if onPath[n] {
+			return 0
+		}
+		if paths, ok := memo[n]; ok {
+			return paths
+		}
+		onPath[n] = true
+		paths := 0
+		for _, m := range g.out[n] {
+			paths += count(m)
+		}
+		delete(onPath, n)
+		memo[n] = paths
+		return paths
+	}
+	return count(from)
+}
+
+func remove[N comparable](nodes []N, n N) []N {
+	kept := make([]N, 0, len(nodes))
+	for _, m := range nodes {
+		if m != n {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
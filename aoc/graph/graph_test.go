@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"aoc"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func rows(text string) []aoc.Line {
+	return aoc.NewInput("graph.txt", []byte(text)).Rows()
+}
+
+func directed(edges ...string) *Graph[string] {
+	g := Directed[string]()
+	for _, e := range edges {
+		from, to, _ := strings.Cut(e, "-")
+		g.AddEdge(from, to)
+	}
+	return g
+}
+
+func TestEdges(t *testing.T) {
+	g := Undirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 1)
+	g.AddEdge(1, 3)
+	g.AddNode(4)
+	g.AddNode(1)
+	if got := g.Nodes(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Nodes = %v", got)
+	}
+	if got := g.Neighbours(1); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Neighbours(1) = %v, want each edge once", got)
+	}
+	if !g.Has(3, 1) || g.Has(2, 3) || g.Neighbours(4) != nil {
+		t.Error("undirected edges don't go both ways")
+	}
+
+	d := directed("a-b")
+	if !d.Has("a", "b") || d.Has("b", "a") {
+		t.Error("directed edge goes both ways")
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		g     *Graph[string]
+		want  []string
+		cycle []string
+	}{
+		{"empty", Directed[string](), nil, nil},
+		{"chain", directed("a-b", "b-c"), []string{"a", "b", "c"}, nil},
+		{"added backwards", directed("c-d", "b-c", "a-b"), []string{"a", "b", "c", "d"}, nil},
+		{"diamond", directed("a-b", "a-c", "b-d", "c-d"), []string{"a", "c", "b", "d"}, nil},
+		{"self loop", directed("a-a"), nil, []string{"a", "a"}},
+		{"cycle", directed("x-a", "a-b", "b-c", "c-a"), nil, []string{"a", "b", "c", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := tt.g.TopoSort()
+			if tt.cycle != nil {
+				var cycle *CycleError[string]
+				if !errors.As(err, &cycle) {
+					t.Fatalf("error %v, want a *CycleError", err)
+				}
+				if !slices.Equal(cycle.Cycle, tt.cycle) {
+					t.Errorf("cycle %v, want %v", cycle.Cycle, tt.cycle)
+				}
+				if order != nil {
+					t.Errorf("ordered %v despite the cycle", order)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(order, tt.want) {
+				t.Errorf("order %v, want %v", order, tt.want)
+			}
+		})
+	}
+
+	if _, err := directed("a-b", "b-a").TopoSort(); err == nil || err.Error() != "graph has a cycle: a -> b -> a" {
+		t.Errorf("error %v", err)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Graph[string]
+		want [][]string
+	}{
+		{"empty", Directed[string](), nil},
+		{"lone node", directed("a-a"), [][]string{{"a"}}},
+		{"chain", directed("a-b", "b-c"), [][]string{{"c"}, {"b"}, {"a"}}},
+		{
+			"two cycles joined",
+			directed("a-b", "b-a", "b-c", "c-d", "d-c"),
+			[][]string{{"c", "d"}, {"a", "b"}},
+		},
+		{
+			"separate",
+			directed("a-b", "b-c", "c-a", "x-y"),
+			[][]string{{"a", "b", "c"}, {"y"}, {"x"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.g.Components()
+			for _, component := range got {
+				slices.Sort(component)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("components %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxClique(t *testing.T) {
+	tests := []struct {
+		name  string
+		edges string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"one edge", "a-b", []string{"a", "b"}},
+		{"triangle and a tail", "a-b\nb-c\nc-a\nc-d", []string{"a", "b", "c"}},
+		{
+			"four beats three",
+			"a-b\nb-c\nc-a\nw-x\nw-y\nw-z\nx-y\nx-z\ny-z\nc-w",
+			[]string{"w", "x", "y", "z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Undirected[string]()
+			if tt.edges != "" {
+				var err error
+				if g, err = ParseEdges(rows(tt.edges), "-", false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := g.MaxClique()
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("clique %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	g := directed("a-b", "a-c", "b-d", "c-d", "d-e", "d-f", "e-g", "f-g")
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{"a", "g", 4},
+		{"d", "g", 2},
+		{"g", "a", 0},
+		{"a", "a", 1},
+	}
+	for _, tt := range tests {
+		if got := g.CountPaths(tt.from, tt.to); got != tt.want {
+			t.Errorf("CountPaths(%s, %s) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	// a cycle off the path is skipped rather than looping forever
+	if got := directed("a-b", "b-a", "b-c").CountPaths("a", "c"); got != 1 {
+		t.Errorf("CountPaths around a cycle = %d, want 1", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	g, err := ParseAdjacency(rows("you: bbb ccc\nbbb: out\nccc: out bbb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Nodes(); !slices.Equal(got, []string{"you", "bbb", "ccc", "out"}) {
+		t.Errorf("Nodes = %v", got)
+	}
+	if got := g.CountPaths("you", "out"); got != 3 {
+		t.Errorf("%d paths, want 3", got)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{"edge without a separator", "kh", "graph.txt:1:1:"},
+		{"edge missing an end", "kh-tc\nkh-", "graph.txt:2:4: expected a node"},
+		{"adjacency without a colon", "aaa bbb", "graph.txt:1:1:"},
+		{"no node before the colon", ": bbb", "graph.txt:1:1: expected a node before the colon"},
+		{"listed twice", "aaa: bbb\naaa: ccc", "graph.txt:2:1: aaa is listed twice"},
+		{"no edges", "aaa:", "graph.txt:1:5: expected the nodes aaa leads to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if strings.HasPrefix(tt.name, "edge") {
+				_, err = ParseEdges(rows(tt.text), "-", false)
+			} else {
+				_, err = ParseAdjacency(rows(tt.text))
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("error %v, want one starting %q", err, tt.err)
+			}
+		})
+	}
+}
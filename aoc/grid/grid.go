@@ -0,0 +1,220 @@
+// Package grid is the rectangle of cells so many puzzles are drawn on. Cells
+// are addressed by a single index, row after row, so they can key maps and
+// slices without building "r_c" strings. Steps between cells are the
+// directions of package point, X going along a row and Y down a column.
+package grid
+
+import (
+	"aoc"
+	"aoc/point"
+	"fmt"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// Grid holds Width*Height cells, row after row.
+type Grid[T any] struct {
+	Width, Height int
+	Cells         []T
+}
+
+// New is a grid with every cell set to fill.
+func New[T any](width, height int, fill T) *Grid[T] {
+	cells := make([]T, width*height)
+	for i := range cells {
+		cells[i] = fill
+	}
+	return &Grid[T]{Width: width, Height: height, Cells: cells}
+}
+
+// Parse builds a grid from rows of characters, like the ones in.Grid checks,
+// turning each character into a cell.
+func Parse[T any](rows []aoc.Line, cell func(c aoc.Line) (T, error)) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return &Grid[T]{}, nil
+	}
+	g := &Grid[T]{Width: utf8.RuneCountInString(rows[0].Text), Height: len(rows)}
+	g.Cells = make([]T, 0, g.Width*g.Height)
+	for _, row := range rows {
+		chars := row.Runes()
+		if len(chars) != g.Width {
+			return nil, row.Errorf("row is %d wide, the first one %d", len(chars), g.Width)
+		}
+		for _, c := range chars {
+			value, err := cell(c)
+			if err != nil {
+				return nil, err
+			}
+			g.Cells = append(g.Cells, value)
+		}
+	}
+	return g, nil
+}
+
+// Runes is a grid of the characters of rows.
+func Runes(rows []aoc.Line) (*Grid[rune], error) {
+	return Parse(rows, func(c aoc.Line) (rune, error) {
+		r, _ := utf8.DecodeRuneInString(c.Text)
+		return r, nil
+	})
+}
+
+// Strings is a grid of the characters of rows, each as a string.
+func Strings(rows []aoc.Line) (*Grid[string], error) {
+	return Parse(rows, func(c aoc.Line) (string, error) {
+		return c.Text, nil
+	})
+}
+
+// Index is the index of the cell at row r and column c.
+func (g *Grid[T]) Index(r, c int) int {
+	return r*g.Width + c
+}
+
+// Pos is the row and column of cell i.
+func (g *Grid[T]) Pos(i int) (r, c int) {
+	return i / g.Width, i % g.Width
+}
+
+// In tells whether row r and column c are on the grid.
+func (g *Grid[T]) In(r, c int) bool {
+	return r >= 0 && r < g.Height && c >= 0 && c < g.Width
+}
+
+// At is cell i.
+func (g *Grid[T]) At(i int) T {
+	return g.Cells[i]
+}
+
+// Set changes cell i.
+func (g *Grid[T]) Set(i int, value T) {
+	g.Cells[i] = value
+}
+
+// Get is the cell at row r and column c, false when that is off the grid.
+func (g *Grid[T]) Get(r, c int) (T, bool) {
+	if !g.In(r, c) {
+		var zero T
+		return zero, false
+	}
+	return g.Cells[g.Index(r, c)], true
+}
+
+// Point is where cell i is, X its column and Y its row.
+func (g *Grid[T]) Point(i int) point.Point {
+	r, c := g.Pos(i)
+	return point.Pt(c, r)
+}
+
+// IndexOf is the index of the cell at p, false when p is off the grid.
+func (g *Grid[T]) IndexOf(p point.Point) (int, bool) {
+	if !g.In(p.Y, p.X) {
+		return -1, false
+	}
+	return g.Index(p.Y, p.X), true
+}
+
+// Step is the index of the cell next to i in direction d, false when that
+// would leave the grid.
+func (g *Grid[T]) Step(i int, d point.Point) (int, bool) {
+	return g.IndexOf(g.Point(i).Add(d))
+}
+
+// Neighbours yields the cells next to i in the given directions, skipping
+// those off the grid.
+func (g *Grid[T]) Neighbours(i int, dirs []point.Point) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, d := range dirs {
+			if n, ok := g.Step(i, d); ok && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Find is the first cell that matches, row after row.
+func (g *Grid[T]) Find(match func(T) bool) (int, bool) {
+	for i, cell := range g.Cells {
+		if match(cell) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// FindAll lists the cells that match, row after row.
+func (g *Grid[T]) FindAll(match func(T) bool) []int {
+	var found []int
+	for i, cell := range g.Cells {
+		if match(cell) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// Row is row r, sharing the grid's cells.
+func (g *Grid[T]) Row(r int) []T {
+	return g.Cells[r*g.Width : (r+1)*g.Width]
+}
+
+// Column is a copy of column c.
+func (g *Grid[T]) Column(c int) []T {
+	column := make([]T, g.Height)
+	for r := range column {
+		column[r] = g.Cells[g.Index(r, c)]
+	}
+	return column
+}
+
+// Rows yields each row, top to bottom, sharing the grid's cells.
+func (g *Grid[T]) Rows() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for r := range g.Height {
+			if !yield(r, g.Row(r)) {
+				return
+			}
+		}
+	}
+}
+
+// Columns yields a copy of each column, left to right.
+func (g *Grid[T]) Columns() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for c := range g.Width {
+			if !yield(c, g.Column(c)) {
+				return
+			}
+		}
+	}
+}
+
+// Clone is a copy of the grid that can be changed on its own.
+func (g *Grid[T]) Clone() *Grid[T] {
+	clone := *g
+	clone.Cells = append([]T(nil), g.Cells...)
+	return &clone
+}
+
+// Render draws the grid a row a line, each cell as cell draws it.
+func (g *Grid[T]) Render(cell func(i int, value T) string) string {
+	var sb strings.Builder
+	for i, value := range g.Cells {
+		sb.WriteString(cell(i, value))
+		if (i+1)%g.Width == 0 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// String draws runes and strings as they are and anything else with fmt.
+func (g *Grid[T]) String() string {
+	return g.Render(func(_ int, value T) string {
+		if r, ok := any(value).(rune); ok {
+			return string(r)
+		}
+		return fmt.Sprint(value)
+	})
+}
@@ -0,0 +1,192 @@
+package grid
+
+import (
+	"aoc"
+	"aoc/point"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func rows(text string) []aoc.Line {
+	return aoc.NewInput("grid.txt", []byte(text)).Rows()
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		width, height int
+		cells         string
+		err           string
+	}{
+		{"square", "ab\ncd", 2, 2, "abcd", ""},
+		{"one row", "#.#.", 4, 1, "#.#.", ""},
+		{"multi-byte", "⏐.\n.⏐", 2, 2, "⏐..⏐", ""},
+		{"ragged", "abc\nab", 0, 0, "", "grid.txt:2:1: row is 2 wide, the first one 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Strings(rows(tt.text))
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Width != tt.width || g.Height != tt.height {
+				t.Errorf("%dx%d, want %dx%d", g.Width, g.Height, tt.width, tt.height)
+			}
+			if got := strings.Join(g.Cells, ""); got != tt.cells {
+				t.Errorf("cells %q, want %q", got, tt.cells)
+			}
+		})
+	}
+
+	if g, err := Parse(nil, func(aoc.Line) (int, error) { return 0, nil }); err != nil || g.Width != 0 || g.Height != 0 {
+		t.Errorf("Parse of no rows = %+v, %v, want an empty grid", g, err)
+	}
+
+	// the cell function's errors come through with its position
+	_, err := Parse(rows("12\n3x"), func(c aoc.Line) (int, error) { return c.Int() })
+	if err == nil || !strings.HasPrefix(err.Error(), "grid.txt:2:2:") {
+		t.Errorf("error %v, want one at grid.txt:2:2", err)
+	}
+}
+
+func TestIndexing(t *testing.T) {
+	g, err := Runes(rows("abc\ndef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		r, c  int
+		index int
+		cell  rune
+		in    bool
+	}{
+		{0, 0, 0, 'a', true},
+		{0, 2, 2, 'c', true},
+		{1, 0, 3, 'd', true},
+		{1, 2, 5, 'f', true},
+		{-1, 0, 0, 0, false},
+		{0, 3, 0, 0, false},
+		{2, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		cell, ok := g.Get(tt.r, tt.c)
+		if ok != tt.in || cell != tt.cell || g.In(tt.r, tt.c) != tt.in {
+			t.Errorf("Get(%d, %d) = %q, %v, want %q, %v", tt.r, tt.c, cell, ok, tt.cell, tt.in)
+		}
+		if !tt.in {
+			if _, ok := g.IndexOf(point.Pt(tt.c, tt.r)); ok {
+				t.Errorf("IndexOf(%d,%d) is on the grid", tt.c, tt.r)
+			}
+			continue
+		}
+		if i := g.Index(tt.r, tt.c); i != tt.index || g.At(i) != tt.cell {
+			t.Errorf("Index(%d, %d) = %d, want %d", tt.r, tt.c, i, tt.index)
+		}
+		if r, c := g.Pos(tt.index); r != tt.r || c != tt.c {
+			t.Errorf("Pos(%d) = %d, %d, want %d, %d", tt.index, r, c, tt.r, tt.c)
+		}
+		if p := g.Point(tt.index); p != point.Pt(tt.c, tt.r) {
+			t.Errorf("Point(%d) = %v", tt.index, p)
+		}
+		if i, ok := g.IndexOf(point.Pt(tt.c, tt.r)); !ok || i != tt.index {
+			t.Errorf("IndexOf(%d,%d) = %d, %v, want %d", tt.c, tt.r, i, ok, tt.index)
+		}
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New(3, 3, '.')
+	tests := []struct {
+		name string
+		i    int
+		dirs []point.Point
+		want []int
+	}{
+		{"middle cardinal", 4, point.Cardinal, []int{1, 5, 7, 3}},
+		{"middle all", 4, point.All, []int{1, 2, 5, 8, 7, 6, 3, 0}},
+		{"corner", 0, point.Cardinal, []int{1, 3}},
+		{"corner diagonal", 8, point.Diagonal, []int{4}},
+		{"edge doesn't wrap", 2, []point.Point{point.Right}, nil},
+		{"left edge doesn't wrap", 3, []point.Point{point.Left}, nil},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(g.Neighbours(tt.i, tt.dirs)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: neighbours of %d = %v, want %v", tt.name, tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestRowsAndColumns(t *testing.T) {
+	g, err := Strings(rows("ab\ncd\nef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Row(1); !slices.Equal(got, []string{"c", "d"}) {
+		t.Errorf("Row(1) = %v", got)
+	}
+	if got := g.Column(1); !slices.Equal(got, []string{"b", "d", "f"}) {
+		t.Errorf("Column(1) = %v", got)
+	}
+
+	var joined []string
+	for _, row := range g.Rows() {
+		joined = append(joined, strings.Join(row, ""))
+	}
+	for _, column := range g.Columns() {
+		joined = append(joined, strings.Join(column, ""))
+	}
+	if want := []string{"ab", "cd", "ef", "ace", "bdf"}; !slices.Equal(joined, want) {
+		t.Errorf("rows then columns %v, want %v", joined, want)
+	}
+
+	// rows share the cells, columns and clones don't
+	g.Column(0)[0] = "x"
+	clone := g.Clone()
+	clone.Set(0, "y")
+	g.Row(0)[1] = "z"
+	if got := g.String(); got != "az\ncd\nef\n" {
+		t.Errorf("grid %q after the changes", got)
+	}
+	if got := clone.String(); got != "yb\ncd\nef\n" {
+		t.Errorf("clone %q after the changes", got)
+	}
+}
+
+func TestFind(t *testing.T) {
+	g, err := Runes(rows("S.#\n#.E\n..#"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, ok := g.Find(func(r rune) bool { return r == 'E' }); !ok || i != 5 {
+		t.Errorf("Find E = %d, %v, want 5", i, ok)
+	}
+	if i, ok := g.Find(func(r rune) bool { return r == 'X' }); ok || i != -1 {
+		t.Errorf("Find X = %d, %v, want -1, false", i, ok)
+	}
+	walls := g.FindAll(func(r rune) bool { return r == '#' })
+	if want := []int{2, 3, 8}; !slices.Equal(walls, want) {
+		t.Errorf("FindAll # = %v, want %v", walls, want)
+	}
+
+	drawn := g.Render(func(i int, r rune) string {
+		if slices.Contains(walls, i) {
+			return "█"
+		}
+		return string(r)
+	})
+	if drawn != "S.█\n█.E\n..█\n" {
+		t.Errorf("Render = %q", drawn)
+	}
+	if got := New(2, 1, 7).String(); got != "77\n" {
+		t.Errorf("String of ints = %q", got)
+	}
+}
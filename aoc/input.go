@@ -0,0 +1,57 @@
+package aoc
+
+import "strings"
+
+// NewInput is the input read from a file, stdin or the site, normalised so
+// days don't have to care where it came from.
+func NewInput(name string, data []byte) Input {
+	return Input{Name: name, Text: Normalise(string(data))}
+}
+
+// Normalise turns text into the shape data.txt has: no byte order mark, "\n"
+// line endings and no blank lines or newline at the end. Spaces ending the
+// last line are kept, some inputs are columns padded to the same width.
+func Normalise(text string) string {
+	text = strings.TrimPrefix(text, "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	for text != "" {
+		i := strings.LastIndexByte(text, '\n')
+		if strings.TrimSpace(text[i+1:]) != "" {
+			break
+		}
+		text = text[:max(i, 0)]
+	}
+	return text
+}
+
+// Sections splits the rows of the input around blank lines, like the rules
+// and updates of 2024 day 5 or the locks and keys of 2024 day 25. Several
+// blank lines in a row separate just two sections.
+func (in Input) Sections() [][]Line {
+	var sections [][]Line
+	var section []Line
+	for _, row := range in.Rows() {
+		if strings.TrimSpace(row.Text) != "" {
+			section = append(section, row)
+			continue
+		}
+		if section != nil {
+			sections = append(sections, section)
+			section = nil
+		}
+	}
+	if section != nil {
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// SectionsN is Sections for inputs made of exactly n sections.
+func (in Input) SectionsN(n int) ([][]Line, error) {
+	sections := in.Sections()
+	if len(sections) != n {
+		return nil, in.Errorf("expected %d sections separated by blank lines, got %d", n, len(sections))
+	}
+	return sections, nil
+}
@@ -0,0 +1,186 @@
+// Package interval is ranges of integers and sets made of them, like the ID
+// ranges of 2025 days 2 and 5. Ranges are held half-open, from Lo up to but
+// not including Hi, and puzzles writing them inclusive, "3-5", build them with
+// Closed instead.
+package interval
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// Interval is the integers from Lo up to but not including Hi. It is empty
+// when Hi isn't past Lo.
+type Interval struct {
+	Lo, Hi int
+}
+
+// HalfOpen is the integers from lo up to but not including hi.
+func HalfOpen(lo, hi int) Interval {
+	return Interval{Lo: lo, Hi: hi}
+}
+
+// Closed is the integers from first to last, both included.
+func Closed(first, last int) Interval {
+	return Interval{Lo: first, Hi: last + 1}
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d,%d)", i.Lo, i.Hi)
+}
+
+// Empty tells whether the interval has no integers.
+func (i Interval) Empty() bool {
+	return i.Hi <= i.Lo
+}
+
+// Len is how many integers the interval has.
+func (i Interval) Len() int {
+	return max(i.Hi-i.Lo, 0)
+}
+
+// Last is the largest integer in the interval, the end of it written closed.
+func (i Interval) Last() int {
+	return i.Hi - 1
+}
+
+// Contains tells whether x is in the interval.
+func (i Interval) Contains(x int) bool {
+	return i.Lo <= x && x < i.Hi
+}
+
+// Overlaps tells whether the intervals share an integer.
+func (i Interval) Overlaps(j Interval) bool {
+	return max(i.Lo, j.Lo) < min(i.Hi, j.Hi)
+}
+
+// Values yields the integers of the interval in order.
+func (i Interval) Values() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for x := i.Lo; x < i.Hi; x++ {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Set is a set of integers kept as the fewest intervals covering them, in
+// order, so lookups are a binary search. The zero Set is empty and ready to
+// use.
+type Set struct {
+	spans []Interval
+}
+
+// New is the set covering all the intervals.
+func New(intervals ...Interval) *Set {
+	s := &Set{}
+	for _, i := range intervals {
+		s.Add(i)
+	}
+	return s
+}
+
+// Add puts the integers of i in the set, merging it with the intervals it
+// overlaps or touches.
+func (s *Set) Add(i Interval) {
+	if i.Empty() {
+		return
+	}
+	lo := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].Hi >= i.Lo })
+	hi := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].Lo > i.Hi })
+	if lo < hi {
+		i.Lo = min(i.Lo, s.spans[lo].Lo)
+		i.Hi = max(i.Hi, s.spans[hi-1].Hi)
+	}
+	s.spans = slices.Replace(s.spans, lo, hi, i)
+}
+
+// Remove takes the integers of i out of the set, splitting an interval it
+// falls in the middle of.
+func (s *Set) Remove(i Interval) {
+	if i.Empty() {
+		return
+	}
+	lo := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].Hi > i.Lo })
+	hi := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].Lo >= i.Hi })
+	if lo >= hi {
+		return
+	}
+	var kept []Interval
+	if first := s.spans[lo]; first.Lo < i.Lo {
+		kept = append(kept, Interval{first.Lo, i.Lo})
+	}
+	if last := s.spans[hi-1]; last.Hi > i.Hi {
+		kept = append(kept, Interval{i.Hi, last.Hi})
+	}
+	s.spans = slices.Replace(s.spans, lo, hi, kept...)
+}
+
+// Intervals lists the intervals of the set in order, none of them touching.
+func (s *Set) Intervals() []Interval {
+	return slices.Clone(s.spans)
+}
+
+// Len is how many integers the set has.
+func (s *Set) Len() int {
+	n := 0
+	for _, i := range s.spans {
+		n += i.Len()
+	}
+	return n
+}
+
+// Contains tells whether x is in the set.
+func (s *Set) Contains(x int) bool {
+	k := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].Hi > x })
+	return k < len(s.spans) && s.spans[k].Lo <= x
+}
+
+// Overlaps tells whether the set has any integer of i.
+func (s *Set) Overlaps(i Interval) bool {
+	if i.Empty() {
+		return false
+	}
+	k := sort.Search(len(s.spans), func(k int) bool { return s.spans[k].Hi > i.Lo })
+	return k < len(s.spans) && s.spans[k].Lo < i.Hi
+}
+
+// Union is the integers in either set.
+func (s *Set) Union(t *Set) *Set {
+	u := &Set{spans: slices.Clone(s.spans)}
+	for _, i := range t.spans {
+		u.Add(i)
+	}
+	return u
+}
+
+// Intersect is the integers in both sets.
+func (s *Set) Intersect(t *Set) *Set {
+	var both []Interval
+	a, b := 0, 0
+	for a < len(s.spans) && b < len(t.spans) {
+		i, j := s.spans[a], t.spans[b]
+		if lo, hi := max(i.Lo, j.Lo), min(i.Hi, j.Hi); lo < hi {
+			both = append(both, Interval{lo, hi})
+		}
+		// the one ending first can't overlap anything else of the other
+		if i.Hi < j.Hi {
+			a++
+		} else {
+			b++
+		}
+	}
+	return &Set{spans: both}
+}
+
+// Subtract is the integers in s but not in t.
+func (s *Set) Subtract(t *Set) *Set {
+	d := &Set{spans: slices.Clone(s.spans)}
+	for _, i := range t.spans {
+		d.Remove(i)
+	}
+	return d
+}
@@ -0,0 +1,173 @@
+package interval
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	i := Closed(3, 5)
+	if i != HalfOpen(3, 6) {
+		t.Fatalf("Closed(3, 5) = %v, want [3,6)", i)
+	}
+	if i.Len() != 3 || i.Last() != 5 || i.Empty() {
+		t.Errorf("%v: Len %d, Last %d, Empty %v", i, i.Len(), i.Last(), i.Empty())
+	}
+	if got := slices.Collect(i.Values()); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("%v values %v, want [3 4 5]", i, got)
+	}
+	for x, want := range map[int]bool{2: false, 3: true, 5: true, 6: false} {
+		if i.Contains(x) != want {
+			t.Errorf("%v contains %d: %v, want %v", i, x, !want, want)
+		}
+	}
+	if empty := HalfOpen(4, 4); !empty.Empty() || empty.Len() != 0 || empty.Contains(4) {
+		t.Errorf("%v should be empty", empty)
+	}
+	if backwards := HalfOpen(5, 2); !backwards.Empty() || backwards.Len() != 0 {
+		t.Errorf("%v should be empty", backwards)
+	}
+
+	overlaps := []struct {
+		a, b Interval
+		want bool
+	}{
+		{HalfOpen(0, 5), HalfOpen(4, 8), true},
+		{HalfOpen(0, 5), HalfOpen(5, 8), false},
+		{Closed(0, 5), Closed(5, 8), true},
+		{HalfOpen(0, 10), HalfOpen(3, 4), true},
+		{HalfOpen(0, 5), HalfOpen(3, 3), false},
+	}
+	for _, tt := range overlaps {
+		if got := tt.a.Overlaps(tt.b); got != tt.want {
+			t.Errorf("%v overlaps %v: %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.Overlaps(tt.a); got != tt.want {
+			t.Errorf("%v overlaps %v: %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestSetAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		add  []Interval
+		want []Interval
+		len  int
+	}{
+		{"nothing", nil, nil, 0},
+		{"empty ones ignored", []Interval{HalfOpen(3, 3), HalfOpen(5, 1)}, nil, 0},
+		{"apart", []Interval{HalfOpen(5, 7), HalfOpen(0, 2)}, []Interval{{0, 2}, {5, 7}}, 4},
+		{"touching halves merge", []Interval{HalfOpen(0, 3), HalfOpen(3, 6)}, []Interval{{0, 6}}, 6},
+		{"touching closed merge", []Interval{Closed(0, 2), Closed(3, 5)}, []Interval{{0, 6}}, 6},
+		{"one apart stay apart", []Interval{Closed(0, 2), Closed(4, 5)}, []Interval{{0, 3}, {4, 6}}, 5},
+		{"overlapping", []Interval{HalfOpen(0, 5), HalfOpen(3, 8)}, []Interval{{0, 8}}, 8},
+		{"inside", []Interval{HalfOpen(0, 10), HalfOpen(3, 4)}, []Interval{{0, 10}}, 10},
+		{"covering", []Interval{HalfOpen(3, 4), HalfOpen(0, 10)}, []Interval{{0, 10}}, 10},
+		{"bridging several", []Interval{HalfOpen(0, 2), HalfOpen(4, 6), HalfOpen(8, 10), HalfOpen(1, 9)}, []Interval{{0, 10}}, 10},
+		{"bridging by touching", []Interval{HalfOpen(0, 2), HalfOpen(4, 6), HalfOpen(2, 4)}, []Interval{{0, 6}}, 6},
+		{"negative", []Interval{Closed(-5, -1), Closed(0, 2)}, []Interval{{-5, 3}}, 8},
+		{"same twice", []Interval{Closed(1, 1), Closed(1, 1)}, []Interval{{1, 2}}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.add...)
+			if got := s.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("intervals %v, want %v", got, tt.want)
+			}
+			if s.Len() != tt.len {
+				t.Errorf("Len = %d, want %d", s.Len(), tt.len)
+			}
+		})
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove Interval
+		want   []Interval
+	}{
+		{"nothing", HalfOpen(3, 3), []Interval{{0, 5}, {10, 15}}},
+		{"outside", HalfOpen(5, 10), []Interval{{0, 5}, {10, 15}}},
+		{"the middle", HalfOpen(2, 3), []Interval{{0, 2}, {3, 5}, {10, 15}}},
+		{"a start", HalfOpen(0, 2), []Interval{{2, 5}, {10, 15}}},
+		{"an end", HalfOpen(4, 5), []Interval{{0, 4}, {10, 15}}},
+		{"a whole one", HalfOpen(10, 15), []Interval{{0, 5}}},
+		{"across both", HalfOpen(3, 12), []Interval{{0, 3}, {12, 15}}},
+		{"everything", HalfOpen(-1, 20), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(HalfOpen(0, 5), HalfOpen(10, 15))
+			s.Remove(tt.remove)
+			if got := s.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("intervals %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLookups(t *testing.T) {
+	s := New(Closed(3, 5), Closed(10, 14), Closed(16, 20))
+	for x, want := range map[int]bool{2: false, 3: true, 5: true, 6: false, 9: false, 10: true, 15: false, 20: true, 21: false} {
+		if got := s.Contains(x); got != want {
+			t.Errorf("contains %d: %v, want %v", x, got, want)
+		}
+	}
+
+	overlaps := []struct {
+		i    Interval
+		want bool
+	}{
+		{HalfOpen(0, 3), false},
+		{HalfOpen(0, 4), true},
+		{HalfOpen(6, 10), false},
+		{HalfOpen(6, 11), true},
+		{HalfOpen(15, 16), false},
+		{HalfOpen(12, 12), false},
+		{HalfOpen(0, 100), true},
+	}
+	for _, tt := range overlaps {
+		if got := s.Overlaps(tt.i); got != tt.want {
+			t.Errorf("overlaps %v: %v, want %v", tt.i, got, tt.want)
+		}
+	}
+
+	var zero Set
+	if zero.Contains(0) || zero.Overlaps(HalfOpen(-10, 10)) || zero.Len() != 0 {
+		t.Error("the zero set isn't empty")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := New(HalfOpen(0, 10), HalfOpen(20, 30))
+	b := New(HalfOpen(5, 20), HalfOpen(25, 26), HalfOpen(40, 50))
+
+	tests := []struct {
+		name string
+		got  *Set
+		want []Interval
+	}{
+		{"union", a.Union(b), []Interval{{0, 30}, {40, 50}}},
+		{"intersect", a.Intersect(b), []Interval{{5, 10}, {25, 26}}},
+		{"intersect the other way", b.Intersect(a), []Interval{{5, 10}, {25, 26}}},
+		{"subtract", a.Subtract(b), []Interval{{0, 5}, {20, 25}, {26, 30}}},
+		{"subtract the other way", b.Subtract(a), []Interval{{10, 20}, {40, 50}}},
+		{"intersect touching", New(HalfOpen(0, 5)).Intersect(New(HalfOpen(5, 10))), nil},
+		{"intersect empty", a.Intersect(&Set{}), nil},
+	}
+	for _, tt := range tests {
+		if got := tt.got.Intervals(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// the operations leave their operands alone
+	if got := a.Intervals(); !slices.Equal(got, []Interval{{0, 10}, {20, 30}}) {
+		t.Errorf("a changed to %v", got)
+	}
+	if got := b.Intervals(); !slices.Equal(got, []Interval{{5, 20}, {25, 26}, {40, 50}}) {
+		t.Errorf("b changed to %v", got)
+	}
+}
@@ -0,0 +1,204 @@
+// Package mathx is the number theory and linear algebra puzzles lean on, done
+// exactly: integer gcd, modular inverses and the Chinese remainder theorem,
+// and linear systems solved over the rationals with math/big, so an answer
+// that has to be a whole number is never a float that nearly is.
+package mathx
+
+import (
+	"errors"
+	"math/big"
+)
+
+// GCD is the greatest common divisor of a and b, never negative. GCD(0, 0)
+// is 0.
+func GCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return abs(a)
+}
+
+// LCM is the least common multiple of a and b, never negative.
+func LCM(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return abs(a / GCD(a, b) * b)
+}
+
+// ExtGCD is the greatest common divisor g of a and b along with x and y such
+// that a*x + b*y = g.
+func ExtGCD(a, b int) (g, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// Mod is a modulo m, between 0 and m-1 even when a is negative.
+func Mod(a, m int) int {
+	return ((a % m) + m) % m
+}
+
+// ModInverse is the x in 0..m-1 with a*x = 1 modulo m, false when a and m
+// share a factor and there isn't one.
+func ModInverse(a, m int) (int, bool) {
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// CRT is the Chinese remainder theorem: the smallest x >= 0 with x = r[i]
+// modulo m[i] for every i, and the modulus every such x repeats with, the lcm
+// of m. The moduli don't have to be coprime. It is false when the
+// congruences contradict each other or the answer doesn't fit in an int.
+func CRT(r, m []int) (x, modulus int, ok bool) {
+	bx, bm := big.NewInt(0), big.NewInt(1)
+	for i := range r {
+		mi := big.NewInt(int64(m[i]))
+		ri := new(big.Int).Mod(big.NewInt(int64(r[i])), mi)
+
+		// x + bm*k = ri (mod mi), solvable when gcd(bm, mi) divides ri - x
+		g, p := new(big.Int), new(big.Int)
+		g.GCD(p, nil, bm, mi)
+		diff := new(big.Int).Sub(ri, bx)
+		if new(big.Int).Mod(diff, g).Sign() != 0 {
+			return 0, 0, false
+		}
+		step := new(big.Int).Div(mi, g)
+		k := new(big.Int).Div(diff, g)
+		k.Mul(k, p).Mod(k, step)
+
+		bx.Add(bx, k.Mul(k, bm))
+		bm.Mul(bm, step)
+		bx.Mod(bx, bm)
+	}
+	if !bm.IsInt64() {
+		return 0, 0, false
+	}
+	return int(bx.Int64()), int(bm.Int64()), true
+}
+
+var (
+	// ErrInconsistent is a linear system no values satisfy.
+	ErrInconsistent = errors.New("the system has no solution")
+	// ErrUnderdetermined is a linear system with more than one solution.
+	ErrUnderdetermined = errors.New("the system has infinitely many solutions")
+)
+
+// Matrix is rows of exact rational numbers. A linear system is its
+// coefficients with the right hand side as the last column.
+type Matrix [][]*big.Rat
+
+// NewMatrix is the matrix of rows of integers.
+func NewMatrix(rows [][]int) Matrix {
+	m := make(Matrix, len(rows))
+	for i, row := range rows {
+		m[i] = make([]*big.Rat, len(row))
+		for j, v := range row {
+			m[i][j] = new(big.Rat).SetInt64(int64(v))
+		}
+	}
+	return m
+}
+
+// Augment is the system of equations a*x = b, b added to a as a last column.
+func Augment(a [][]int, b []int) Matrix {
+	rows := make([][]int, len(a))
+	for i, row := range a {
+		rows[i] = append(append([]int(nil), row...), b[i])
+	}
+	return NewMatrix(rows)
+}
+
+// Reduce brings m to reduced row echelon form in place with Gauss-Jordan
+// elimination, and returns the column of the leading 1 of each non-zero row,
+// which are the first rows.
+func (m Matrix) Reduce() []int {
+	var pivots []int
+	row := 0
+	for col := 0; len(m) > 0 && col < len(m[0]) && row < len(m); col++ {
+		pivot := -1
+		for r := row; r < len(m); r++ {
+			if m[r][col].Sign() != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		m[row], m[pivot] = m[pivot], m[row]
+
+		inv := new(big.Rat).Inv(m[row][col])
+		for c := col; c < len(m[row]); c++ {
+			m[row][c].Mul(m[row][c], inv)
+		}
+		for r := range m {
+			if r == row || m[r][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(m[r][col])
+			for c := col; c < len(m[r]); c++ {
+				m[r][c].Sub(m[r][c], new(big.Rat).Mul(factor, m[row][c]))
+			}
+		}
+		pivots = append(pivots, col)
+		row++
+	}
+	return pivots
+}
+
+// Solve is the one x with a*x = b. It fails with ErrInconsistent when there
+// is none and ErrUnderdetermined when there are many.
+func Solve(a [][]int, b []int) ([]*big.Rat, error) {
+	m := Augment(a, b)
+	pivots := m.Reduce()
+	vars := len(m[0]) - 1
+	if len(pivots) > 0 && pivots[len(pivots)-1] == vars {
+		return nil, ErrInconsistent
+	}
+	if len(pivots) < vars {
+		return nil, ErrUnderdetermined
+	}
+	x := make([]*big.Rat, vars)
+	for i := range x {
+		x[i] = m[i][vars]
+	}
+	return x, nil
+}
+
+// SolveInt is the one x with a*x = b when every value of it is a whole
+// number, false when there is no such x or more than one x at all.
+func SolveInt(a [][]int, b []int) ([]int, bool) {
+	x, err := Solve(a, b)
+	if err != nil {
+		return nil, false
+	}
+	ints := make([]int, len(x))
+	for i, v := range x {
+		if !v.IsInt() || !v.Num().IsInt64() {
+			return nil, false
+		}
+		ints[i] = int(v.Num().Int64())
+	}
+	return ints, true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,127 @@
+package mathx
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestGCD(t *testing.T) {
+	tests := []struct {
+		a, b     int
+		gcd, lcm int
+	}{
+		{12, 18, 6, 36},
+		{7, 13, 1, 91},
+		{0, 5, 5, 0},
+		{0, 0, 0, 0},
+		{-4, 6, 2, 12},
+		{4, -6, 2, 12},
+	}
+	for _, tt := range tests {
+		if got := GCD(tt.a, tt.b); got != tt.gcd {
+			t.Errorf("GCD(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.gcd)
+		}
+		if got := LCM(tt.a, tt.b); got != tt.lcm {
+			t.Errorf("LCM(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.lcm)
+		}
+		g, x, y := ExtGCD(tt.a, tt.b)
+		if g != tt.gcd || tt.a*x+tt.b*y != g {
+			t.Errorf("ExtGCD(%d, %d) = %d, %d, %d", tt.a, tt.b, g, x, y)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	tests := []struct {
+		a, m int
+		want int
+		ok   bool
+	}{
+		{3, 7, 5, true},
+		{-3, 7, 2, true},
+		{10, 17, 12, true},
+		{1, 2, 1, true},
+		{4, 6, 0, false},
+		{0, 5, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ModInverse(tt.a, tt.m)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ModInverse(%d, %d) = %d, %v, want %d, %v", tt.a, tt.m, got, ok, tt.want, tt.ok)
+		}
+	}
+	if got := Mod(-7, 5); got != 3 {
+		t.Errorf("Mod(-7, 5) = %d, want 3", got)
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, m    []int
+		x, modm int
+		ok      bool
+	}{
+		{"none", nil, nil, 0, 1, true},
+		{"one", []int{8}, []int{5}, 3, 5, true},
+		{"coprime", []int{2, 3, 2}, []int{3, 5, 7}, 23, 105, true},
+		{"negative remainders", []int{-1, -1}, []int{4, 9}, 35, 36, true},
+		{"shared factor", []int{2, 8}, []int{6, 10}, 8, 30, true},
+		{"shared factor contradicts", []int{1, 2}, []int{6, 10}, 0, 0, false},
+		{"one divides the other", []int{3, 7}, []int{4, 8}, 7, 8, true},
+		{"same modulus twice", []int{3, 3}, []int{7, 7}, 3, 7, true},
+		{"same modulus contradicts", []int{3, 4}, []int{7, 7}, 0, 0, false},
+		{"puzzle grid", []int{68, 46}, []int{101, 103}, 1179, 10403, true},
+		{"too big for an int", []int{0, 0, 0}, []int{1 << 30, 1<<31 - 1, 1<<31 - 3}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, modulus, ok := CRT(tt.r, tt.m)
+			if x != tt.x || modulus != tt.modm || ok != tt.ok {
+				t.Fatalf("CRT(%v, %v) = %d, %d, %v, want %d, %d, %v", tt.r, tt.m, x, modulus, ok, tt.x, tt.modm, tt.ok)
+			}
+			for i := range tt.r {
+				if ok && Mod(x, tt.m[i]) != Mod(tt.r[i], tt.m[i]) {
+					t.Errorf("%d isn't %d modulo %d", x, tt.r[i], tt.m[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name string
+		a    [][]int
+		b    []int
+		want []*big.Rat
+		err  error
+	}{
+		{"whole", [][]int{{94, 22}, {34, 67}}, []int{8400, 5400}, []*big.Rat{big.NewRat(80, 1), big.NewRat(40, 1)}, nil},
+		{"fractions", [][]int{{2, 0}, {0, 3}}, []int{1, 1}, []*big.Rat{big.NewRat(1, 2), big.NewRat(1, 3)}, nil},
+		{"needs a swap", [][]int{{0, 1}, {1, 0}}, []int{4, 5}, []*big.Rat{big.NewRat(5, 1), big.NewRat(4, 1)}, nil},
+		{"redundant row", [][]int{{1, 1}, {2, 2}, {1, -1}}, []int{4, 8, 0}, []*big.Rat{big.NewRat(2, 1), big.NewRat(2, 1)}, nil},
+		{"inconsistent", [][]int{{1, 1}, {2, 2}}, []int{4, 9}, nil, ErrInconsistent},
+		{"underdetermined", [][]int{{1, 1}, {2, 2}}, []int{4, 8}, nil, ErrUnderdetermined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Solve(tt.a, tt.b)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error %v, want %v", err, tt.err)
+			}
+			if !slices.EqualFunc(got, tt.want, func(x, y *big.Rat) bool { return x.Cmp(y) == 0 }) {
+				t.Errorf("x = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if x, ok := SolveInt([][]int{{94, 22}, {34, 67}}, []int{8400, 5400}); !ok || !slices.Equal(x, []int{80, 40}) {
+		t.Errorf("SolveInt = %v, %v, want [80 40]", x, ok)
+	}
+	if _, ok := SolveInt([][]int{{2, 0}, {0, 3}}, []int{1, 1}); ok {
+		t.Error("SolveInt found whole numbers for a fractional solution")
+	}
+}
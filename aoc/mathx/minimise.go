@@ -0,0 +1,171 @@
+package mathx
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrNoIntegerSolution is a linear system with no solution in non-negative
+// integers.
+var ErrNoIntegerSolution = errors.New("the system has no non-negative integer solution")
+
+// MinimiseInt is the x of non-negative integers with a*x = b that costs the
+// least, the cost of x being the sum of cost[j]*x[j], and that cost. It fails
+// with ErrNoIntegerSolution when there is no such x.
+//
+// a, b and cost can't be negative, which bounds each variable by the
+// smallest b of the equations it is in. Gauss-Jordan elimination over the
+// rationals leaves every pivot variable a function of the free ones, and the
+// free ones are searched within their bounds, each pivot variable worked out
+// as soon as all the free variables its equation has are.
+func MinimiseInt(a [][]int, b []int, cost []int) ([]int, int, error) {
+	vars := len(cost)
+	for j := range cost {
+		if cost[j] < 0 {
+			return nil, 0, errors.New("MinimiseInt needs costs that aren't negative")
+		}
+	}
+	m := Augment(a, b)
+	pivots := m.Reduce()
+	if len(pivots) > 0 && pivots[len(pivots)-1] == vars {
+		return nil, 0, ErrNoIntegerSolution
+	}
+
+	bound := make([]int, vars)
+	for j := range bound {
+		bound[j] = -1
+		for i, row := range a {
+			if row[j] < 0 || b[i] < 0 {
+				return nil, 0, errors.New("MinimiseInt needs a and b without negative values")
+			}
+			if row[j] > 0 && (bound[j] < 0 || b[i]/row[j] < bound[j]) {
+				bound[j] = b[i] / row[j]
+			}
+		}
+		if bound[j] < 0 {
+			// in no equation, so it might as well be 0
+			bound[j] = 0
+		}
+	}
+
+	isPivot := make([]bool, vars)
+	for _, p := range pivots {
+		isPivot[p] = true
+	}
+	var free []int
+	for j := range vars {
+		if !isPivot[j] {
+			free = append(free, j)
+		}
+	}
+
+	// each pivot row as integers: scale*x[pivot] + sum of coef[f]*x[free[f]] = rhs
+	type row struct {
+		pivot int
+		scale int
+		coef  []int
+		rhs   int
+		// last is the index in free of the last variable the row needs
+		last int
+	}
+	rows := make([]row, len(pivots))
+	byLast := make([][]int, len(free)+1)
+	for r, p := range pivots {
+		scale := big.NewInt(1)
+		for _, f := range free {
+			scale = lcm(scale, m[r][f].Denom())
+		}
+		scale = lcm(scale, m[r][vars].Denom())
+
+		rw := row{pivot: p, coef: make([]int, len(free)), last: -1}
+		var ok bool
+		if rw.scale, ok = scaled(big.NewRat(1, 1), scale); !ok {
+			return nil, 0, errOverflow
+		}
+		for k, f := range free {
+			if rw.coef[k], ok = scaled(m[r][f], scale); !ok {
+				return nil, 0, errOverflow
+			}
+			if rw.coef[k] != 0 {
+				rw.last = k
+			}
+		}
+		if rw.rhs, ok = scaled(m[r][vars], scale); !ok {
+			return nil, 0, errOverflow
+		}
+		rows[r] = rw
+		byLast[rw.last+1] = append(byLast[rw.last+1], r)
+	}
+
+	x := make([]int, vars)
+	best, bestCost, found := make([]int, vars), 0, false
+
+	// settle works out the pivot variables of rows, false when one of them
+	// isn't a non-negative integer
+	settle := func(rs []int) bool {
+		for _, r := range rs {
+			rw := rows[r]
+			rest := rw.rhs
+			for k, f := range free {
+				rest -= rw.coef[k] * x[f]
+			}
+			if rest < 0 || rest%rw.scale != 0 {
+				return false
+			}
+			x[rw.pivot] = rest / rw.scale
+		}
+		return true
+	}
+
+	var search func(k, spent int)
+	search = func(k, spent int) {
+		if found && spent >= bestCost {
+			return
+		}
+		if !settle(byLast[k]) {
+			return
+		}
+		if k == len(free) {
+			total := 0
+			for j, v := range x {
+				total += cost[j] * v
+			}
+			if !found || total < bestCost {
+				copy(best, x)
+				bestCost, found = total, true
+			}
+			return
+		}
+		f := free[k]
+		for v := 0; v <= bound[f]; v++ {
+			if found && cost[f] > 0 && spent+cost[f]*v >= bestCost {
+				break
+			}
+			x[f] = v
+			search(k+1, spent+cost[f]*v)
+		}
+		x[f] = 0
+	}
+	search(0, 0)
+
+	if !found {
+		return nil, 0, ErrNoIntegerSolution
+	}
+	return best, bestCost, nil
+}
+
+var errOverflow = errors.New("MinimiseInt coefficients overflow an int")
+
+func lcm(a, b *big.Int) *big.Int {
+	g := new(big.Int).GCD(nil, nil, a, b)
+	return new(big.Int).Mul(new(big.Int).Div(a, g), b)
+}
+
+// scaled is r*scale as an int, false when it isn't one.
+func scaled(r *big.Rat, scale *big.Int) (int, bool) {
+	v := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+	if !v.IsInt() || !v.Num().IsInt64() {
+		return 0, false
+	}
+	return int(v.Num().Int64()), true
+}
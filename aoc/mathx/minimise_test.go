@@ -0,0 +1,200 @@
+package mathx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMinimiseInt(t *testing.T) {
+	tests := []struct {
+		name string
+		a    [][]int
+		b    []int
+		cost []int
+		want int
+		err  error
+	}{
+		{
+			// the buttons and joltages of the 2025 day 10 example
+			name: "fewest presses",
+			a: [][]int{
+				{0, 0, 0, 0, 1, 1},
+				{0, 1, 0, 0, 0, 1},
+				{0, 0, 1, 1, 1, 0},
+				{1, 1, 0, 1, 0, 0},
+			},
+			b:    []int{3, 5, 4, 7},
+			cost: []int{1, 1, 1, 1, 1, 1},
+			want: 10,
+		},
+		{
+			name: "unique",
+			a:    [][]int{{1, 0}, {0, 1}},
+			b:    []int{3, 4},
+			cost: []int{2, 5},
+			want: 26,
+		},
+		{
+			name: "cheaper to use the dearer one less",
+			a:    [][]int{{1, 2}},
+			b:    []int{10},
+			cost: []int{1, 1},
+			want: 5,
+		},
+		{
+			name: "cost decides",
+			a:    [][]int{{1, 2}},
+			b:    []int{10},
+			cost: []int{1, 3},
+			want: 10,
+		},
+		{
+			name: "free variable in no equation",
+			a:    [][]int{{1, 0}},
+			b:    []int{4},
+			cost: []int{1, 1},
+			want: 4,
+		},
+		{
+			name: "zero cost variables",
+			a:    [][]int{{1, 1}},
+			b:    []int{6},
+			cost: []int{0, 1},
+			want: 0,
+		},
+		{
+			name: "all zero",
+			a:    [][]int{{1, 1}, {1, 0}},
+			b:    []int{0, 0},
+			cost: []int{1, 1},
+			want: 0,
+		},
+		{
+			name: "fractional pivot",
+			a:    [][]int{{2, 1}, {0, 1}},
+			b:    []int{4, 1},
+			cost: []int{1, 1},
+			err:  ErrNoIntegerSolution,
+		},
+		{
+			name: "would need a negative",
+			a:    [][]int{{1, 1}, {0, 1}},
+			b:    []int{2, 3},
+			cost: []int{1, 1},
+			err:  ErrNoIntegerSolution,
+		},
+		{
+			name: "inconsistent",
+			a:    [][]int{{1, 1}, {1, 1}},
+			b:    []int{2, 3},
+			cost: []int{1, 1},
+			err:  ErrNoIntegerSolution,
+		},
+		{
+			name: "parity",
+			a:    [][]int{{2, 2}},
+			b:    []int{5},
+			cost: []int{1, 1},
+			err:  ErrNoIntegerSolution,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, cost, err := MinimiseInt(tt.a, tt.b, tt.cost)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if cost != tt.want {
+				t.Errorf("cost %d with %v, want %d", cost, x, tt.want)
+			}
+			checkSolution(t, tt.a, tt.b, tt.cost, x, cost)
+
+			// nothing cheaper that brute force can find
+			if best, ok := bruteForce(tt.a, tt.b, tt.cost); !ok || best != cost {
+				t.Errorf("brute force costs %d, %v, MinimiseInt %d", best, ok, cost)
+			}
+		})
+	}
+}
+
+func TestMinimiseIntRejectsNegatives(t *testing.T) {
+	tests := []struct {
+		name string
+		a    [][]int
+		b    []int
+		cost []int
+	}{
+		{"negative cost", [][]int{{1, 1}}, []int{2}, []int{1, -1}},
+		{"negative coefficient", [][]int{{1, -1}}, []int{2}, []int{1, 1}},
+		{"negative target", [][]int{{1, 1}}, []int{-2}, []int{1, 1}},
+	}
+	for _, tt := range tests {
+		if _, _, err := MinimiseInt(tt.a, tt.b, tt.cost); err == nil || errors.Is(err, ErrNoIntegerSolution) {
+			t.Errorf("%s: error %v, want one about negative values", tt.name, err)
+		}
+	}
+}
+
+func checkSolution(t *testing.T, a [][]int, b, cost, x []int, total int) {
+	t.Helper()
+	sum := 0
+	for j, v := range x {
+		if v < 0 {
+			t.Fatalf("x = %v has a negative value", x)
+		}
+		sum += cost[j] * v
+	}
+	if sum != total {
+		t.Errorf("x = %v costs %d, not %d", x, sum, total)
+	}
+	for i, row := range a {
+		got := 0
+		for j, v := range x {
+			got += row[j] * v
+		}
+		if got != b[i] {
+			t.Errorf("x = %v gives %d for equation %d, want %d", x, got, i, b[i])
+		}
+	}
+}
+
+// bruteForce tries every x up to the largest b.
+func bruteForce(a [][]int, b, cost []int) (int, bool) {
+	limit := 0
+	for _, v := range b {
+		limit = max(limit, v)
+	}
+	x := make([]int, len(cost))
+	best, found := 0, false
+	var try func(j int)
+	try = func(j int) {
+		if j == len(x) {
+			for i, row := range a {
+				sum := 0
+				for k, v := range x {
+					sum += row[k] * v
+				}
+				if sum != b[i] {
+					return
+				}
+			}
+			total := 0
+			for k, v := range x {
+				total += cost[k] * v
+			}
+			if !found || total < best {
+				best, found = total, true
+			}
+			return
+		}
+		for v := 0; v <= limit; v++ {
+			x[j] = v
+			try(j + 1)
+		}
+	}
+	try(0)
+	return best, found
+}
@@ -0,0 +1,113 @@
+// Package memo caches the results of recursive solvers by their arguments.
+// Keys are comparable values, a struct of the arguments or integers packed
+// into one, so looking a result up doesn't format or allocate anything.
+package memo
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Table maps keys to the values worked out for them, counting how often a
+// lookup found one.
+type Table[K comparable, V any] struct {
+	// mu is only taken by tables made with NewSync
+	mu     *sync.Mutex
+	values map[K]V
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// New is an empty table for a single goroutine.
+func New[K comparable, V any]() *Table[K, V] {
+	return &Table[K, V]{values: make(map[K]V)}
+}
+
+// NewSync is an empty table that goroutines can share.
+func NewSync[K comparable, V any]() *Table[K, V] {
+	return &Table[K, V]{mu: &sync.Mutex{}, values: make(map[K]V)}
+}
+
+// Get is the value stored for key, false when there is none yet.
+func (t *Table[K, V]) Get(key K) (V, bool) {
+	if t.mu != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	value, ok := t.values[key]
+	if ok {
+		t.hits.Add(1)
+	} else {
+		t.misses.Add(1)
+	}
+	return value, ok
+}
+
+// Set stores value for key.
+func (t *Table[K, V]) Set(key K, value V) {
+	if t.mu != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.values[key] = value
+}
+
+// Do is the value stored for key, working it out with compute and storing it
+// the first time. compute runs without holding the table, so it can recurse
+// into Do, and goroutines sharing a table may each work out the same key once.
+func (t *Table[K, V]) Do(key K, compute func() V) V {
+	if value, ok := t.Get(key); ok {
+		return value
+	}
+	value := compute()
+	t.Set(key, value)
+	return value
+}
+
+// Len is how many keys have a value.
+func (t *Table[K, V]) Len() int {
+	if t.mu != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	return len(t.values)
+}
+
+// Stats is how a table has been used.
+type Stats struct {
+	Hits, Misses int
+	Entries      int
+}
+
+// Stats counts the lookups so far and the values stored.
+func (t *Table[K, V]) Stats() Stats {
+	return Stats{Hits: int(t.hits.Load()), Misses: int(t.misses.Load()), Entries: t.Len()}
+}
+
+func (s Stats) String() string {
+	rate := 0.0
+	if lookups := s.Hits + s.Misses; lookups > 0 {
+		rate = 100 * float64(s.Hits) / float64(lookups)
+	}
+	return fmt.Sprintf("%d hits, %d misses (%.1f%% hit rate), %d entries", s.Hits, s.Misses, rate, s.Entries)
+}
+
+// Widths of the integers Pack2 packs, a value and a small one like a depth or
+// a column.
+const (
+	PackHighBits = 48
+	PackLowBits  = 64 - PackHighBits
+)
+
+// Pack2 is a key made of two integers, a in the high PackHighBits bits and b
+// in the low PackLowBits bits, both signed. Integers that don't fit wrap and
+// share keys with others, so a must be within ±2^47 and b within ±2^15.
+func Pack2(a, b int) uint64 {
+	return uint64(a)<<PackLowBits | uint64(b)&(1<<PackLowBits-1)
+}
+
+// Unpack2 is the two integers Pack2 packed.
+func Unpack2(key uint64) (a, b int) {
+	return int(int64(key) >> PackLowBits), int(int64(key<<PackHighBits) >> PackHighBits)
+}
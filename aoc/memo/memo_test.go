@@ -0,0 +1,105 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPack2(t *testing.T) {
+	const (
+		maxHigh = 1<<(PackHighBits-1) - 1
+		minHigh = -1 << (PackHighBits - 1)
+		maxLow  = 1<<(PackLowBits-1) - 1
+		minLow  = -1 << (PackLowBits - 1)
+	)
+	tests := []struct {
+		a, b int
+	}{
+		{0, 0},
+		{1, 2},
+		{409526509568, 75},
+		{-1, 0},
+		{0, -1},
+		{-1, -1},
+		{-7, 3},
+		{7, -3},
+		{maxHigh, maxLow},
+		{minHigh, minLow},
+		{maxHigh, minLow},
+		{minHigh, maxLow},
+	}
+	keys := make(map[uint64][2]int)
+	for _, tt := range tests {
+		key := Pack2(tt.a, tt.b)
+		if a, b := Unpack2(key); a != tt.a || b != tt.b {
+			t.Errorf("Unpack2(Pack2(%d, %d)) = %d, %d", tt.a, tt.b, a, b)
+		}
+		if other, ok := keys[key]; ok {
+			t.Errorf("Pack2(%d, %d) and Pack2(%d, %d) are both %#x", tt.a, tt.b, other[0], other[1], key)
+		}
+		keys[key] = [2]int{tt.a, tt.b}
+	}
+
+	// one past the limits wraps around
+	if a, _ := Unpack2(Pack2(maxHigh+1, 0)); a != minHigh {
+		t.Errorf("a past the high bits unpacked to %d, want it wrapped to %d", a, minHigh)
+	}
+	if _, b := Unpack2(Pack2(0, maxLow+1)); b != minLow {
+		t.Errorf("b past the low bits unpacked to %d, want it wrapped to %d", b, minLow)
+	}
+}
+
+func TestTable(t *testing.T) {
+	calls := 0
+	var fib func(n int) int
+	table := New[int, int]()
+	fib = func(n int) int {
+		if n < 2 {
+			return n
+		}
+		return table.Do(n, func() int {
+			calls++
+			return fib(n-1) + fib(n-2)
+		})
+	}
+	if got := fib(50); got != 12586269025 {
+		t.Fatalf("fib(50) = %d", got)
+	}
+	if calls != 49 {
+		t.Errorf("worked out %d values, want each of the 49 once", calls)
+	}
+	stats := table.Stats()
+	if stats.Entries != 49 || stats.Misses != 49 || stats.Hits != 47 {
+		t.Errorf("stats %+v, want 49 entries and misses and 47 hits", stats)
+	}
+
+	if _, ok := table.Get(100); ok {
+		t.Error("Get found a value never set")
+	}
+	table.Set(100, 1)
+	if v, ok := table.Get(100); !ok || v != 1 {
+		t.Errorf("Get after Set = %d, %v", v, ok)
+	}
+}
+
+func TestSyncTable(t *testing.T) {
+	table := NewSync[uint64, int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				table.Do(Pack2(i, g%2), func() int { return i })
+			}
+		}()
+	}
+	wg.Wait()
+	if n := table.Len(); n != 2000 {
+		t.Errorf("%d entries, want 2000", n)
+	}
+	stats := table.Stats()
+	if stats.Hits+stats.Misses != 8000 {
+		t.Errorf("%d lookups counted, want 8000", stats.Hits+stats.Misses)
+	}
+}
@@ -0,0 +1,245 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is malformed input, pointing at where in the input it is.
+type ParseError struct {
+	File string
+	// Line and Col are 1-based. A zero Col is about the whole line, a zero
+	// Line about the whole input.
+	Line, Col int
+	Msg       string
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Col > 0:
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Col, e.Msg)
+	case e.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Msg)
+}
+
+// Errorf reports a problem with the input as a whole.
+func (in Input) Errorf(format string, args ...any) error {
+	return &ParseError{File: in.Name, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Line is a line of the input, or a piece of one, that knows where it is, so
+// errors about it can say so.
+type Line struct {
+	Text string
+	file string
+	// num is the 1-based line number and col the 1-based column Text starts at.
+	num, col int
+}
+
+// Rows splits the normalised input into lines that know their position.
+func (in Input) Rows() []Line {
+	texts := strings.Split(Normalise(in.Text), "\n")
+	rows := make([]Line, len(texts))
+	for i, t := range texts {
+		rows[i] = Line{Text: t, file: in.Name, num: i + 1, col: 1}
+	}
+	return rows
+}
+
+func (l Line) String() string {
+	return l.Text
+}
+
+// Num is the line's 1-based line number.
+func (l Line) Num() int {
+	return l.num
+}
+
+// Errorf reports a problem at the start of the line.
+func (l Line) Errorf(format string, args ...any) error {
+	return &ParseError{File: l.file, Line: l.num, Col: l.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Slice is the piece of the line between byte offsets i and j.
+func (l Line) Slice(i, j int) Line {
+	return Line{Text: l.Text[i:j], file: l.file, num: l.num, col: l.col + utf8.RuneCountInString(l.Text[:i])}
+}
+
+// Runes splits the line into its characters.
+func (l Line) Runes() []Line {
+	var runes []Line
+	for i, r := range l.Text {
+		runes = append(runes, l.Slice(i, i+utf8.RuneLen(r)))
+	}
+	return runes
+}
+
+// Split cuts the line around every sep.
+func (l Line) Split(sep string) []Line {
+	var pieces []Line
+	start := 0
+	for _, piece := range strings.Split(l.Text, sep) {
+		pieces = append(pieces, l.Slice(start, start+len(piece)))
+		start += len(piece) + len(sep)
+	}
+	return pieces
+}
+
+// SplitN splits the line into exactly n pieces around sep.
+func (l Line) SplitN(sep string, n int) ([]Line, error) {
+	pieces := l.Split(sep)
+	if len(pieces) != n {
+		return nil, l.Errorf("expected %d values separated by %q, got %d in %q", n, sep, len(pieces), l.Text)
+	}
+	return pieces, nil
+}
+
+// Fields splits the line around runs of spaces.
+func (l Line) Fields() []Line {
+	var fields []Line
+	start := -1
+	for i, r := range l.Text + " " {
+		switch {
+		case r == ' ' || r == '\t':
+			if start >= 0 {
+				fields = append(fields, l.Slice(start, i))
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+	return fields
+}
+
+// Cut slices the line around the first sep, which has to be there.
+func (l Line) Cut(sep string) (before, after Line, err error) {
+	i := strings.Index(l.Text, sep)
+	if i < 0 {
+		return Line{}, Line{}, l.Errorf("expected %q in %q", sep, l.Text)
+	}
+	return l.Slice(0, i), l.Slice(i+len(sep), len(l.Text)), nil
+}
+
+// CutPrefix is the rest of a line starting with prefix, which it has to.
+func (l Line) CutPrefix(prefix string) (Line, error) {
+	if !strings.HasPrefix(l.Text, prefix) {
+		return Line{}, l.Errorf("expected %q, got %q", prefix, l.Text)
+	}
+	return l.Slice(len(prefix), len(l.Text)), nil
+}
+
+// Int parses the whole line as a decimal integer.
+func (l Line) Int() (int, error) {
+	n, err := strconv.Atoi(l.Text)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, l.Errorf("%s is out of range", l.Text)
+		}
+		return 0, l.Errorf("expected a number, got %q", l.Text)
+	}
+	return n, nil
+}
+
+// Float parses the whole line as a decimal number.
+func (l Line) Float() (float64, error) {
+	f, err := strconv.ParseFloat(l.Text, 64)
+	if err != nil {
+		return 0, l.Errorf("expected a number, got %q", l.Text)
+	}
+	return f, nil
+}
+
+// Ints parses the numbers separated by sep, or by spaces when sep is empty.
+func (l Line) Ints(sep string) ([]int, error) {
+	pieces := l.Fields()
+	if sep != "" {
+		pieces = l.Split(sep)
+	}
+	numbers := make([]int, len(pieces))
+	for i, piece := range pieces {
+		n, err := piece.Int()
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// Digits parses each character of the line as a digit.
+func (l Line) Digits() ([]int, error) {
+	digits := make([]int, 0, len(l.Text))
+	for _, r := range l.Runes() {
+		if len(r.Text) != 1 || r.Text[0] < '0' || r.Text[0] > '9' {
+			return nil, r.Errorf("expected a digit, got %q", r.Text)
+		}
+		digits = append(digits, int(r.Text[0]-'0'))
+	}
+	return digits, nil
+}
+
+// Match matches the whole line against re and returns the submatches.
+func (l Line) Match(re *regexp.Regexp) ([]Line, error) {
+	indices := re.FindStringSubmatchIndex(l.Text)
+	if indices == nil || indices[0] != 0 || indices[1] != len(l.Text) {
+		return nil, l.Errorf("expected a line like %s, got %q", re, l.Text)
+	}
+	groups := make([]Line, len(indices)/2-1)
+	for i := range groups {
+		if start := indices[2*i+2]; start >= 0 {
+			groups[i] = l.Slice(start, indices[2*i+3])
+		}
+	}
+	return groups, nil
+}
+
+// Scan is fmt.Sscanf over the whole line: every verb has to match and nothing
+// may follow the last one.
+func (l Line) Scan(format string, args ...any) error {
+	var rest string
+	n, err := fmt.Sscanf(l.Text, format+"%s", append(args, &rest)...)
+	switch {
+	case n == len(args)+1:
+		return l.Errorf("unexpected %q at the end of %q", rest, l.Text)
+	case n == len(args) && (err == io.EOF || err == io.ErrUnexpectedEOF):
+		return nil
+	}
+	return l.Errorf("expected a line like %q, got %q", format, l.Text)
+}
+
+// Grid is the rows of an input that has to be a grid: rows as wide as the
+// first one, holding only allowed characters. An empty allowed lets any
+// character through.
+func (in Input) Grid(allowed string) ([]Line, error) {
+	return GridOf(in, in.Rows(), allowed)
+}
+
+// GridOf checks that rows, a section of the input, are a grid like Grid does.
+func GridOf(in Input, rows []Line, allowed string) ([]Line, error) {
+	if len(rows) == 0 || rows[0].Text == "" {
+		return nil, in.Errorf("expected a grid, got nothing")
+	}
+	width := utf8.RuneCountInString(rows[0].Text)
+	for _, row := range rows {
+		if w := utf8.RuneCountInString(row.Text); w != width {
+			return nil, row.Errorf("row is %d wide, the first one %d", w, width)
+		}
+		if allowed == "" {
+			continue
+		}
+		for _, r := range row.Runes() {
+			if !strings.Contains(allowed, r.Text) {
+				return nil, r.Errorf("unexpected %q, expected one of %q", r.Text, allowed)
+			}
+		}
+	}
+	return rows, nil
+}
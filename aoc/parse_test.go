@@ -0,0 +1,248 @@
+package aoc
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func line(text string) Line {
+	return NewInput("in.txt", []byte(text)).Rows()[0]
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		err  *ParseError
+		want string
+	}{
+		{&ParseError{File: "in.txt", Line: 3, Col: 7, Msg: "bad"}, "in.txt:3:7: bad"},
+		{&ParseError{File: "in.txt", Line: 3, Msg: "bad"}, "in.txt:3: bad"},
+		{&ParseError{File: "in.txt", Msg: "bad"}, "in.txt: bad"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+
+	rows := NewInput("in.txt", []byte("a\r\nb\n\n")).Rows()
+	if len(rows) != 2 || rows[1].Text != "b" || rows[1].Num() != 2 {
+		t.Fatalf("rows %q", rows)
+	}
+	if got := rows[1].Errorf("no %s", "b").Error(); got != "in.txt:2:1: no b" {
+		t.Errorf("Errorf = %q", got)
+	}
+	if got := NewInput("in.txt", nil).Errorf("empty").Error(); got != "in.txt: empty" {
+		t.Errorf("input Errorf = %q", got)
+	}
+}
+
+// pos is where an error about l points.
+func pos(l Line) string {
+	msg := l.Errorf("").Error()
+	return strings.TrimSuffix(msg, ": ")
+}
+
+func TestPositions(t *testing.T) {
+	fields := line("  12\t 3").Fields()
+	split := line("ä b,cé,d").Split(",")
+	runes := line("aé→b").Runes()
+	before, after, err := line("日本: x y").Cut(": ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, err := line("p=→1").CutPrefix("p=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		l    Line
+		text string
+		pos  string
+	}{
+		{"first field", fields[0], "12", "in.txt:1:3"},
+		{"field after a tab", fields[1], "3", "in.txt:1:7"},
+		{"split after a multi-byte rune", split[1], "cé", "in.txt:1:5"},
+		{"split after two", split[2], "d", "in.txt:1:8"},
+		{"rune after a two-byte one", runes[2], "→", "in.txt:1:3"},
+		{"rune after a three-byte one", runes[3], "b", "in.txt:1:4"},
+		{"before the cut", before, "日本", "in.txt:1:1"},
+		{"after the cut", after, "x y", "in.txt:1:5"},
+		{"field of a slice", after.Fields()[1], "y", "in.txt:1:7"},
+		{"after the prefix", rest, "→1", "in.txt:1:3"},
+		{"slice of a slice", rest.Slice(len("→"), len(rest.Text)), "1", "in.txt:1:4"},
+	}
+	for _, tt := range tests {
+		if tt.l.Text != tt.text || pos(tt.l) != tt.pos {
+			t.Errorf("%s: %q at %s, want %q at %s", tt.name, tt.l.Text, pos(tt.l), tt.text, tt.pos)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		sep  string
+		want []int
+		err  string
+	}{
+		{"spaces", "1  -2 30", "", []int{1, -2, 30}, ""},
+		{"commas", "7,8,9", ",", []int{7, 8, 9}, ""},
+		{"bad number", "1,2,x", ",", nil, `in.txt:1:5: expected a number, got "x"`},
+		{"empty piece", "1,,2", ",", nil, `in.txt:1:3: expected a number, got ""`},
+		{"after multi-byte", "1 → 2", "", nil, `in.txt:1:3: expected a number, got "→"`},
+		{"too big", "99999999999999999999", "", nil, "in.txt:1:1: 99999999999999999999 is out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := line(tt.text).Ints(tt.sep)
+			checkErr(t, err, tt.err)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Ints = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigits(t *testing.T) {
+	tests := []struct {
+		text string
+		want []int
+		err  string
+	}{
+		{"2333133121414131402", []int{2, 3, 3, 3, 1, 3, 3, 1, 2, 1, 4, 1, 4, 1, 3, 1, 4, 0, 2}, ""},
+		{"", []int{}, ""},
+		{"12a", nil, `in.txt:1:3: expected a digit, got "a"`},
+		{"1²3", nil, `in.txt:1:2: expected a digit, got "²"`},
+		{"é5x", nil, `in.txt:1:1: expected a digit, got "é"`},
+		{"-1", nil, `in.txt:1:1: expected a digit, got "-"`},
+	}
+	for _, tt := range tests {
+		got, err := line(tt.text).Digits()
+		checkErr(t, err, tt.err)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Digits(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSplitN(t *testing.T) {
+	pieces, err := line("ab→cd→é").SplitN("→", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos(pieces[2]) != "in.txt:1:7" {
+		t.Errorf("last piece at %s, want in.txt:1:7", pos(pieces[2]))
+	}
+	_, err = line("a-b-c").SplitN("-", 2)
+	checkErr(t, err, `in.txt:1:1: expected 2 values separated by "-", got 3 in "a-b-c"`)
+}
+
+func TestMatch(t *testing.T) {
+	re := regexp.MustCompile(`(\S+) → (\d+)(?:, (\d+))?`)
+	tests := []struct {
+		name   string
+		text   string
+		groups []string
+		pos    []string
+		err    string
+	}{
+		{"all groups", "aé → 12, 3", []string{"aé", "12", "3"}, []string{"in.txt:1:1", "in.txt:1:6", "in.txt:1:10"}, ""},
+		{"optional group missing", "x → 7", []string{"x", "7", ""}, []string{"in.txt:1:1", "in.txt:1:5", ""}, ""},
+		{"trailing text", "x → 7!", nil, nil, `in.txt:1:1: expected a line like`},
+		{"leading text", " x → 7", nil, nil, `in.txt:1:1: expected a line like`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := line(tt.text).Match(re)
+			checkErr(t, err, tt.err)
+			for i, g := range groups {
+				if g.Text != tt.groups[i] {
+					t.Errorf("group %d = %q, want %q", i, g.Text, tt.groups[i])
+				}
+				if tt.pos[i] != "" && pos(g) != tt.pos[i] {
+					t.Errorf("group %d at %s, want %s", i, pos(g), tt.pos[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		x, y int
+		err  string
+	}{
+		{"whole line", "p=3,-4", 3, -4, ""},
+		{"trailing word", "p=3,-4 v=1", 0, 0, `in.txt:1:1: unexpected "v=1" at the end of "p=3,-4 v=1"`},
+		{"trailing text after a number", "p=3,-4x", 0, 0, `in.txt:1:1: unexpected "x" at the end`},
+		{"too short", "p=3", 0, 0, `in.txt:1:1: expected a line like "p=%d,%d", got "p=3"`},
+		{"wrong literal", "q=3,4", 0, 0, `in.txt:1:1: expected a line like "p=%d,%d"`},
+		{"not a number", "p=3,y", 0, 0, `in.txt:1:1: expected a line like "p=%d,%d"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var x, y int
+			err := line(tt.text).Scan("p=%d,%d", &x, &y)
+			checkErr(t, err, tt.err)
+			if err == nil && (x != tt.x || y != tt.y) {
+				t.Errorf("scanned %d,%d, want %d,%d", x, y, tt.x, tt.y)
+			}
+		})
+	}
+
+	// the error points at the line, wherever it is in the input
+	row := NewInput("in.txt", []byte("p=1,2\np=3,4,5")).Rows()[1]
+	var x, y int
+	checkErr(t, row.Scan("p=%d,%d", &x, &y), `in.txt:2:1: unexpected ",5"`)
+}
+
+func TestGrid(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		allowed string
+		err     string
+	}{
+		{"grid", "#.\n.#", "#.", ""},
+		{"anything allowed", "ab\n→c", "", ""},
+		{"empty", "", "#.", "in.txt: expected a grid, got nothing"},
+		{"ragged", "#.\n.#.", "#.", "in.txt:2:1: row is 3 wide, the first one 2"},
+		{"multi-byte counts once", "→.\n..", "", ""},
+		{"bad character", "#.\n→x", "#.→", `in.txt:2:2: unexpected "x", expected one of "#.→"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewInput("in.txt", []byte(tt.text))
+			rows, err := in.Grid(tt.allowed)
+			checkErr(t, err, tt.err)
+			if err == nil && len(rows) != strings.Count(tt.text, "\n")+1 {
+				t.Errorf("%d rows", len(rows))
+			}
+		})
+	}
+
+	// GridOf checks a section, with positions in the whole input
+	in := NewInput("in.txt", []byte("rules\n\n..\n.x"))
+	_, err := GridOf(in, in.Sections()[1], ".")
+	checkErr(t, err, `in.txt:4:2: unexpected "x"`)
+	_, err = GridOf(in, nil, ".")
+	checkErr(t, err, "in.txt: expected a grid, got nothing")
+}
+
+// checkErr checks err starts with want, or is nil when want is empty.
+func checkErr(t *testing.T, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Errorf("unexpected error %v", err)
+	case want != "" && (err == nil || !strings.HasPrefix(err.Error(), want)):
+		t.Errorf("error %v, want one starting %q", err, want)
+	}
+}
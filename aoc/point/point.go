@@ -0,0 +1,165 @@
+// Package point is integer points and vectors on the plane and in space. On
+// the plane Y grows downwards, the way puzzle inputs are read, so Up is 0,-1.
+package point
+
+import (
+	"aoc"
+	"fmt"
+)
+
+// Point is a position or a step on the plane.
+type Point struct {
+	X, Y int
+}
+
+// Pt is shorthand for Point{X: x, Y: y}.
+func Pt(x, y int) Point {
+	return Point{X: x, Y: y}
+}
+
+var (
+	Up    = Pt(0, -1)
+	Right = Pt(1, 0)
+	Down  = Pt(0, 1)
+	Left  = Pt(-1, 0)
+
+	// Cardinal goes clockwise from Up, so the next one is a right turn.
+	Cardinal = []Point{Up, Right, Down, Left}
+	// Diagonal goes clockwise from up and right.
+	Diagonal = []Point{Pt(1, -1), Pt(1, 1), Pt(-1, 1), Pt(-1, -1)}
+	// All eight neighbouring steps, clockwise from Up.
+	All = []Point{Up, Pt(1, -1), Right, Pt(1, 1), Down, Pt(-1, 1), Left, Pt(-1, -1)}
+)
+
+// Parse reads a point written "x,y".
+func Parse(line aoc.Line) (Point, error) {
+	var p Point
+	if err := line.Scan("%d,%d", &p.X, &p.Y); err != nil {
+		return Point{}, err
+	}
+	return p, nil
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("%d,%d", p.X, p.Y)
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Scale multiplies both coordinates by k.
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Neg is the opposite step.
+func (p Point) Neg() Point {
+	return Point{-p.X, -p.Y}
+}
+
+// TurnRight is the step a quarter turn clockwise, as seen on screen.
+func (p Point) TurnRight() Point {
+	return Point{-p.Y, p.X}
+}
+
+// TurnLeft is the step a quarter turn anticlockwise, as seen on screen.
+func (p Point) TurnLeft() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Manhattan is the distance between p and q walking along the axes.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Chebyshev is the distance between p and q moving like a chess king.
+func (p Point) Chebyshev(q Point) int {
+	return max(abs(p.X-q.X), abs(p.Y-q.Y))
+}
+
+// In tells whether p lies in the width by height rectangle at the origin.
+func (p Point) In(width, height int) bool {
+	return p.X >= 0 && p.X < width && p.Y >= 0 && p.Y < height
+}
+
+// Wrap brings p back into the width by height rectangle at the origin, as if
+// it went out one side and came in the other.
+func (p Point) Wrap(width, height int) Point {
+	return Point{mod(p.X, width), mod(p.Y, height)}
+}
+
+// DistanceSq is the square of the straight line distance between p and q.
+func (p Point) DistanceSq(q Point) int {
+	d := p.Sub(q)
+	return d.X*d.X + d.Y*d.Y
+}
+
+// Point3 is a position or a step in space.
+type Point3 struct {
+	X, Y, Z int
+}
+
+// Pt3 is shorthand for Point3{X: x, Y: y, Z: z}.
+func Pt3(x, y, z int) Point3 {
+	return Point3{X: x, Y: y, Z: z}
+}
+
+// Parse3 reads a point written "x,y,z".
+func Parse3(line aoc.Line) (Point3, error) {
+	var p Point3
+	if err := line.Scan("%d,%d,%d", &p.X, &p.Y, &p.Z); err != nil {
+		return Point3{}, err
+	}
+	return p, nil
+}
+
+func (p Point3) String() string {
+	return fmt.Sprintf("%d,%d,%d", p.X, p.Y, p.Z)
+}
+
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+// Scale multiplies every coordinate by k.
+func (p Point3) Scale(k int) Point3 {
+	return Point3{p.X * k, p.Y * k, p.Z * k}
+}
+
+// Manhattan is the distance between p and q walking along the axes.
+func (p Point3) Manhattan(q Point3) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y) + abs(p.Z-q.Z)
+}
+
+// Chebyshev is the largest difference between p and q along an axis.
+func (p Point3) Chebyshev(q Point3) int {
+	return max(abs(p.X-q.X), abs(p.Y-q.Y), abs(p.Z-q.Z))
+}
+
+// DistanceSq is the square of the straight line distance between p and q,
+// exact where the distance itself wouldn't be.
+func (p Point3) DistanceSq(q Point3) int {
+	d := p.Sub(q)
+	return d.X*d.X + d.Y*d.Y + d.Z*d.Z
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// mod is n modulo m, never negative.
+func mod(n, m int) int {
+	return ((n % m) + m) % m
+}
@@ -0,0 +1,121 @@
+package point
+
+import (
+	"aoc"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want Point
+		err  string
+	}{
+		{"3,4", Pt(3, 4), ""},
+		{"-3,0", Pt(-3, 0), ""},
+		{"3 4", Point{}, "points.txt:1:1:"},
+		{"3,4,5", Point{}, "points.txt:1:1:"},
+		{"3,", Point{}, "points.txt:1:1:"},
+	}
+	for _, tt := range tests {
+		line := aoc.NewInput("points.txt", []byte(tt.text)).Rows()[0]
+		got, err := Parse(line)
+		switch {
+		case tt.err == "" && (err != nil || got != tt.want):
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.text, got, err, tt.want)
+		case tt.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.err)):
+			t.Errorf("Parse(%q) = %v, %v, want an error at %s", tt.text, got, err, tt.err)
+		}
+	}
+
+	line := aoc.NewInput("points.txt", []byte("1,-2,3")).Rows()[0]
+	if p, err := Parse3(line); err != nil || p != Pt3(1, -2, 3) {
+		t.Errorf("Parse3(%q) = %v, %v", line, p, err)
+	}
+}
+
+func TestTurns(t *testing.T) {
+	for i, d := range Cardinal {
+		right := Cardinal[(i+1)%4]
+		if got := d.TurnRight(); got != right {
+			t.Errorf("%v turned right = %v, want %v", d, got, right)
+		}
+		if got := right.TurnLeft(); got != d {
+			t.Errorf("%v turned left = %v, want %v", right, got, d)
+		}
+		if got := d.TurnRight().TurnRight(); got != d.Neg() {
+			t.Errorf("%v turned round = %v, want %v", d, got, d.Neg())
+		}
+	}
+	for i, d := range All {
+		if got := All[(i+2)%8]; d.TurnRight() != got {
+			t.Errorf("%v turned right = %v, want %v", d, d.TurnRight(), got)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	p, q := Pt(2, -3), Pt(-1, 5)
+	tests := []struct {
+		name string
+		got  Point
+		want Point
+	}{
+		{"add", p.Add(q), Pt(1, 2)},
+		{"sub", p.Sub(q), Pt(3, -8)},
+		{"scale", p.Scale(3), Pt(6, -9)},
+		{"neg", p.Neg(), Pt(-2, 3)},
+		{"wrap inside", Pt(3, 4).Wrap(11, 7), Pt(3, 4)},
+		{"wrap negative", Pt(-1, -8).Wrap(11, 7), Pt(10, 6)},
+		{"wrap past the end", Pt(23, 7).Wrap(11, 7), Pt(1, 0)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if d := p.Manhattan(q); d != 11 {
+		t.Errorf("Manhattan = %d, want 11", d)
+	}
+	if d := p.Chebyshev(q); d != 8 {
+		t.Errorf("Chebyshev = %d, want 8", d)
+	}
+	if d := p.DistanceSq(q); d != 73 {
+		t.Errorf("DistanceSq = %d, want 73", d)
+	}
+	if s := p.String(); s != "2,-3" {
+		t.Errorf("String = %q", s)
+	}
+
+	a, b := Pt3(1, 2, 3), Pt3(4, -2, 3)
+	if got := a.Add(b); got != Pt3(5, 0, 6) {
+		t.Errorf("Add = %v", got)
+	}
+	if got := a.Sub(b).Scale(2); got != Pt3(-6, 8, 0) {
+		t.Errorf("Sub then Scale = %v", got)
+	}
+	if a.Manhattan(b) != 7 || a.Chebyshev(b) != 4 || a.DistanceSq(b) != 25 {
+		t.Errorf("distances %d %d %d, want 7 4 25", a.Manhattan(b), a.Chebyshev(b), a.DistanceSq(b))
+	}
+}
+
+func TestIn(t *testing.T) {
+	tests := []struct {
+		p    Point
+		want bool
+	}{
+		{Pt(0, 0), true},
+		{Pt(6, 4), true},
+		{Pt(7, 4), false},
+		{Pt(6, 5), false},
+		{Pt(-1, 0), false},
+		{Pt(0, -1), false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.In(7, 5); got != tt.want {
+			t.Errorf("%v in 7x5: %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package pq is a priority queue of values of any type, lowest priority out
+// first. Values pushed with the same priority come out in the order they went
+// in, so a search using it explores ties the same way on every run.
+package pq
+
+import "container/heap"
+
+// Queue is a min-priority queue. The zero Queue is empty and ready to use.
+type Queue[T any] struct {
+	items items[T]
+	seq   int
+}
+
+// Push adds value with the given priority.
+func (q *Queue[T]) Push(value T, priority int) {
+	heap.Push(&q.items, item[T]{value: value, priority: priority, seq: q.seq})
+	q.seq++
+}
+
+// Pop takes out the value with the lowest priority, along with it. It panics
+// on an empty queue.
+func (q *Queue[T]) Pop() (T, int) {
+	it := heap.Pop(&q.items).(item[T])
+	return it.value, it.priority
+}
+
+// Peek is the value Pop would take out next, without taking it.
+func (q *Queue[T]) Peek() (T, int) {
+	return q.items[0].value, q.items[0].priority
+}
+
+// Len is how many values are queued.
+func (q *Queue[T]) Len() int {
+	return len(q.items)
+}
+
+type item[T any] struct {
+	value    T
+	priority int
+	seq      int
+}
+
+// items is the heap.Interface behind Queue, kept out of its API so values
+// can only go in and out through the typed methods.
+type items[T any] []item[T]
+
+func (h items[T]) Len() int { return len(h) }
+
+func (h items[T]) Less(a, b int) bool {
+	if h[a].priority != h[b].priority {
+		return h[a].priority < h[b].priority
+	}
+	return h[a].seq < h[b].seq
+}
+
+func (h items[T]) Swap(a, b int) { h[a], h[b] = h[b], h[a] }
+
+func (h *items[T]) Push(x any) { *h = append(*h, x.(item[T])) }
+
+func (h *items[T]) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,71 @@
+package pq
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	type push struct {
+		value    string
+		priority int
+	}
+	tests := []struct {
+		name   string
+		pushes []push
+		want   []string
+	}{
+		{"empty", nil, nil},
+		{"one", []push{{"a", 3}}, []string{"a"}},
+		{"lowest first", []push{{"c", 3}, {"a", 1}, {"b", 2}}, []string{"a", "b", "c"}},
+		{"negative", []push{{"b", 0}, {"a", -5}}, []string{"a", "b"}},
+		{"ties in order", []push{{"x", 1}, {"y", 0}, {"z", 1}, {"w", 1}, {"v", 0}}, []string{"y", "v", "x", "z", "w"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q Queue[string]
+			for _, p := range tt.pushes {
+				q.Push(p.value, p.priority)
+			}
+			if q.Len() != len(tt.pushes) {
+				t.Fatalf("Len = %d, want %d", q.Len(), len(tt.pushes))
+			}
+			var got []string
+			last := 0
+			for q.Len() > 0 {
+				peeked, peekedPriority := q.Peek()
+				value, priority := q.Pop()
+				if value != peeked || priority != peekedPriority {
+					t.Fatalf("Peek = %s %d, then Pop = %s %d", peeked, peekedPriority, value, priority)
+				}
+				if len(got) > 0 && priority < last {
+					t.Fatalf("popped priority %d after %d", priority, last)
+				}
+				got = append(got, value)
+				last = priority
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("popped %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueueInterleaved(t *testing.T) {
+	var q Queue[int]
+	q.Push(5, 5)
+	q.Push(1, 1)
+	if v, _ := q.Pop(); v != 1 {
+		t.Fatalf("Pop = %d, want 1", v)
+	}
+	q.Push(3, 3)
+	q.Push(7, 7)
+	var got []int
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		got = append(got, v)
+	}
+	if want := []int{3, 5, 7}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+// Package search finds shortest paths through any space of states: squares
+// of a maze, a reindeer's position and heading, a robot's buttons. A search
+// only needs where it starts, which states follow each one and when it has
+// arrived, so the puzzle keeps its own idea of what a state is.
+package search
+
+import "aoc/pq"
+
+// Edge is a step to state To, costing Cost. Costs can't be negative.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Path is a shortest path, from the start to the goal it reached.
+type Path[S comparable] struct {
+	States []S
+	Cost   int
+}
+
+// BFS is the path from start to a goal state taking the fewest steps, each of
+// them costing 1. It is false when no goal can be reached.
+func BFS[S comparable](start S, next func(S) []S, goal func(S) bool) (Path[S], bool) {
+	prev := make(map[S]S)
+	seen := map[S]bool{start: true}
+	frontier := []S{start}
+	for len(frontier) > 0 {
+		current := frontier[0]
+		frontier = frontier[1:]
+		if goal(current) {
+			states := walk(prev, start, current)
+			return Path[S]{States: states, Cost: len(states) - 1}, true
+		}
+		for _, n := range next(current) {
+			if !seen[n] {
+				seen[n] = true
+				prev[n] = current
+				frontier = append(frontier, n)
+			}
+		}
+	}
+	return Path[S]{}, false
+}
+
+// Dijkstra is the cheapest path from start to a goal state. It is false when
+// no goal can be reached.
+func Dijkstra[S comparable](start S, next func(S) []Edge[S], goal func(S) bool) (Path[S], bool) {
+	return AStar(start, next, goal, func(S) int { return 0 })
+}
+
+// AStar is Dijkstra heading for the goal first, guided by heuristic, a guess
+// of the cost left from a state. The guess must never be more than the real
+// cost, nor drop by more than the cost of an edge, or the path found may not
+// be the cheapest.
+func AStar[S comparable](start S, next func(S) []Edge[S], goal func(S) bool, heuristic func(S) int) (Path[S], bool) {
+	dist := map[S]int{start: 0}
+	prev := make(map[S]S)
+	done := make(map[S]bool)
+
+	var queue pq.Queue[S]
+	queue.Push(start, heuristic(start))
+	for queue.Len() > 0 {
+		current, _ := queue.Pop()
+		if done[current] {
+			continue
+		}
+		done[current] = true
+
+		if goal(current) {
+			return Path[S]{States: walk(prev, start, current), Cost: dist[current]}, true
+		}
+		for _, e := range next(current) {
+			cost := dist[current] + e.Cost
+			if d, seen := dist[e.To]; seen && d <= cost {
+				continue
+			}
+			dist[e.To] = cost
+			prev[e.To] = current
+			queue.Push(e.To, cost+heuristic(e.To))
+		}
+	}
+	return Path[S]{}, false
+}
+
+// Tree is every shortest path from a start to the goals at the cheapest cost,
+// as the states each state is reached from on one of them.
+type Tree[S comparable] struct {
+	// Cost is the cost of reaching any of Goals.
+	Cost  int
+	Goals []S
+	// Dist is the cheapest cost of every state the search reached.
+	Dist map[S]int
+	// Prev lists, for each state, the states before it on a shortest path.
+	Prev map[S][]S
+}
+
+// AllShortest is Dijkstra keeping every shortest path instead of one. It
+// stops at goals rather than going through them, and is false when no goal
+// can be reached.
+func AllShortest[S comparable](start S, next func(S) []Edge[S], goal func(S) bool) (*Tree[S], bool) {
+	t := &Tree[S]{Dist: map[S]int{start: 0}, Prev: make(map[S][]S)}
+	done := make(map[S]bool)
+
+	var queue pq.Queue[S]
+	queue.Push(start, 0)
+	for queue.Len() > 0 {
+		current, cost := queue.Pop()
+		if len(t.Goals) > 0 && cost > t.Cost {
+			break
+		}
+		if done[current] {
+			continue
+		}
+		done[current] = true
+
+		if goal(current) {
+			t.Cost = cost
+			t.Goals = append(t.Goals, current)
+			continue
+		}
+		for _, e := range next(current) {
+			c := cost + e.Cost
+			d, seen := t.Dist[e.To]
+			switch {
+			case !seen || c < d:
+				t.Dist[e.To] = c
+				t.Prev[e.To] = []S{current}
+				queue.Push(e.To, c)
+			case c == d:
+				t.Prev[e.To] = append(t.Prev[e.To], current)
+			}
+		}
+	}
+	return t, len(t.Goals) > 0
+}
+
+// States lists every state on any of the shortest paths, in no particular
+// order.
+func (t *Tree[S]) States() []S {
+	seen := make(map[S]bool)
+	var states []S
+	stack := append([]S(nil), t.Goals...)
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		states = append(states, s)
+		stack = append(stack, t.Prev[s]...)
+	}
+	return states
+}
+
+// walk follows prev back from end to start and returns the states in between
+// the right way round.
+func walk[S comparable](prev map[S]S, start, end S) []S {
+	states := []S{end}
+	for s := end; s != start; {
+		s = prev[s]
+		states = append(states, s)
+	}
+	for i, j := 0, len(states)-1; i < j; i, j = i+1, j-1 {
+		states[i], states[j] = states[j], states[i]
+	}
+	return states
+}
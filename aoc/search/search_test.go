@@ -0,0 +1,188 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+// maze is a grid of open '.' squares and '#' walls, searched square by square.
+type maze []string
+
+type square struct{ r, c int }
+
+func (m maze) next(s square) []square {
+	var next []square
+	for _, d := range []square{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+		n := square{s.r + d.r, s.c + d.c}
+		if n.r >= 0 && n.r < len(m) && n.c >= 0 && n.c < len(m[n.r]) && m[n.r][n.c] != '#' {
+			next = append(next, n)
+		}
+	}
+	return next
+}
+
+func (m maze) edges(s square) []Edge[square] {
+	var edges []Edge[square]
+	for _, n := range m.next(s) {
+		edges = append(edges, Edge[square]{To: n, Cost: 1})
+	}
+	return edges
+}
+
+func TestBFS(t *testing.T) {
+	tests := []struct {
+		name  string
+		maze  maze
+		goal  square
+		steps int
+		ok    bool
+	}{
+		{"start is goal", maze{"..."}, square{0, 0}, 0, true},
+		{"straight", maze{"....."}, square{0, 4}, 4, true},
+		{"around a wall", maze{
+			"..#..",
+			"..#..",
+			".....",
+		}, square{0, 4}, 8, true},
+		{"walled off", maze{
+			"..#..",
+			"..#..",
+		}, square{0, 4}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := func(s square) bool { return s == tt.goal }
+			path, ok := BFS(square{0, 0}, tt.maze.next, goal)
+			if ok != tt.ok {
+				t.Fatalf("BFS found a path: %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			checkPath(t, path, square{0, 0}, tt.goal, tt.steps)
+
+			// Dijkstra and AStar agree when every step costs 1
+			dijkstra, _ := Dijkstra(square{0, 0}, tt.maze.edges, goal)
+			checkPath(t, dijkstra, square{0, 0}, tt.goal, tt.steps)
+			manhattan := func(s square) int { return abs(s.r-tt.goal.r) + abs(s.c-tt.goal.c) }
+			astar, _ := AStar(square{0, 0}, tt.maze.edges, goal, manhattan)
+			checkPath(t, astar, square{0, 0}, tt.goal, tt.steps)
+		})
+	}
+}
+
+func checkPath(t *testing.T, path Path[square], start, goal square, cost int) {
+	t.Helper()
+	if path.Cost != cost {
+		t.Errorf("cost %d, want %d", path.Cost, cost)
+	}
+	if len(path.States) == 0 || path.States[0] != start || path.States[len(path.States)-1] != goal {
+		t.Fatalf("path %v doesn't go from %v to %v", path.States, start, goal)
+	}
+	for i := 1; i < len(path.States); i++ {
+		a, b := path.States[i-1], path.States[i]
+		if abs(a.r-b.r)+abs(a.c-b.c) != 1 {
+			t.Fatalf("path %v jumps from %v to %v", path.States, a, b)
+		}
+	}
+}
+
+// graph is weighted edges between numbered nodes.
+type graph map[int][]Edge[int]
+
+func (g graph) next(n int) []Edge[int] { return g[n] }
+
+func TestDijkstra(t *testing.T) {
+	tests := []struct {
+		name   string
+		graph  graph
+		goal   int
+		states []int
+		cost   int
+		ok     bool
+	}{
+		{"direct", graph{0: {{1, 4}}}, 1, []int{0, 1}, 4, true},
+		{"cheaper the long way", graph{
+			0: {{1, 10}, {2, 1}},
+			2: {{3, 1}},
+			3: {{1, 1}},
+		}, 1, []int{0, 2, 3, 1}, 3, true},
+		{"free edges", graph{
+			0: {{1, 0}, {2, 5}},
+			1: {{2, 0}},
+		}, 2, []int{0, 1, 2}, 0, true},
+		{"cycle", graph{
+			0: {{1, 1}},
+			1: {{0, 1}, {2, 2}},
+			2: {{1, 1}},
+		}, 2, []int{0, 1, 2}, 3, true},
+		{"unreachable", graph{0: {{1, 1}}, 2: {{3, 1}}}, 3, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := Dijkstra(0, tt.graph.next, func(n int) bool { return n == tt.goal })
+			if ok != tt.ok {
+				t.Fatalf("found a path: %v, want %v", ok, tt.ok)
+			}
+			if path.Cost != tt.cost || !slices.Equal(path.States, tt.states) {
+				t.Errorf("path %v costing %d, want %v costing %d", path.States, path.Cost, tt.states, tt.cost)
+			}
+		})
+	}
+}
+
+func TestAllShortest(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph graph
+		goals []int
+		// reached are the goals at the cheapest cost
+		reached []int
+		cost    int
+		states  []int
+		ok      bool
+	}{
+		{"one path", graph{0: {{1, 1}}, 1: {{2, 1}}}, []int{2}, []int{2}, 2, []int{0, 1, 2}, true},
+		{"diamond", graph{
+			0: {{1, 1}, {2, 1}},
+			1: {{3, 1}},
+			2: {{3, 1}},
+		}, []int{3}, []int{3}, 2, []int{0, 1, 2, 3}, true},
+		{"dearer branch left out", graph{
+			0: {{1, 1}, {2, 2}},
+			1: {{3, 1}},
+			2: {{3, 1}},
+		}, []int{3}, []int{3}, 2, []int{0, 1, 3}, true},
+		{"two goals at the same cost", graph{
+			0: {{1, 1}, {2, 1}, {3, 5}},
+		}, []int{1, 2, 3}, []int{1, 2}, 1, []int{0, 1, 2}, true},
+		{"stops at a goal", graph{
+			0: {{1, 1}},
+			1: {{2, 1}},
+		}, []int{1, 2}, []int{1}, 1, []int{0, 1}, true},
+		{"unreachable", graph{0: {{1, 1}}}, []int{2}, nil, 0, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, ok := AllShortest(0, tt.graph.next, func(n int) bool { return slices.Contains(tt.goals, n) })
+			if ok != tt.ok {
+				t.Fatalf("found a path: %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			reached := slices.Sorted(slices.Values(tree.Goals))
+			states := slices.Sorted(slices.Values(tree.States()))
+			if tree.Cost != tt.cost || !slices.Equal(reached, tt.reached) || !slices.Equal(states, tt.states) {
+				t.Errorf("goals %v at %d through %v, want %v at %d through %v", reached, tree.Cost, states, tt.reached, tt.cost, tt.states)
+			}
+		})
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,42 @@
+package main
+
+// every solved day registers itself with the aoc package on import
+import (
+	_ "aoc2024/1"
+	_ "aoc2024/10"
+	_ "aoc2024/11"
+	_ "aoc2024/12"
+	_ "aoc2024/13"
+	_ "aoc2024/14"
+	_ "aoc2024/15"
+	_ "aoc2024/16"
+	_ "aoc2024/17"
+	_ "aoc2024/18"
+	_ "aoc2024/19"
+	_ "aoc2024/2"
+	_ "aoc2024/20"
+	_ "aoc2024/21"
+	_ "aoc2024/22"
+	_ "aoc2024/23"
+	_ "aoc2024/24"
+	_ "aoc2024/25"
+	_ "aoc2024/3"
+	_ "aoc2024/4"
+	_ "aoc2024/5"
+	_ "aoc2024/6"
+	_ "aoc2024/7"
+	_ "aoc2024/8"
+	_ "aoc2024/9"
+	_ "aoc2025/day1"
+	_ "aoc2025/day10"
+	_ "aoc2025/day11"
+	_ "aoc2025/day12"
+	_ "aoc2025/day2"
+	_ "aoc2025/day3"
+	_ "aoc2025/day4"
+	_ "aoc2025/day5"
+	_ "aoc2025/day6"
+	_ "aoc2025/day7"
+	_ "aoc2025/day8"
+	_ "aoc2025/day9"
+)
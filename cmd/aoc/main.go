@@ -0,0 +1,570 @@
+package main
+
+import (
+	"advent-code/internal/bench"
+	"advent-code/internal/golden"
+	"advent-code/internal/inputs"
+	"advent-code/internal/parity"
+	"advent-code/internal/runner"
+	"advent-code/internal/scaffold"
+	"advent-code/internal/submit"
+	"aoc"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const usage = `usage: aoc <command> [flags] [args]
+
+commands:
+  run [flags] <year|all> [day|all]      solve days against their data.txt
+  run [flags] <day directory>           solve the day living in that directory
+  check [-update] <year|all> [day|all]  compare answers with answers.txt
+  bench [flags] <year|all> [day|all]    time parse and parts, variants included
+  report [flags] [year|all] [day|all]   show saved bench runs and flag regressions
+  parity [-n 1] <year|all> [day|all]    compare the answers of the Go, Rust and JS solutions
+  fetch [-import] <year|all> [day|all]  fill the input cache, from the site or the committed data.txt
+  submit <year> <day> <part> [answer]   send an answer, the day's own when left out
+  fake-server [flags]                   serve the committed inputs and answers like the puzzle site does
+  new <year> <day>                      create a day from the README skeleton, registered with the runner
+  list [year|all]                       show the registered days
+
+run flags:
+  -visual            render the visualisations of days that have one
+  -examples          solve the examples in each day's examples/ first
+  -param name=value  override a day's param, e.g. -param connectionCount=10
+  -input path        solve one day against a file, every file in a directory, or - for stdin
+
+bench flags:
+  -n 10              timed runs of each step
+  -warmup 2          untimed runs before those
+  -param name=value  as for run
+  -save              append the results to bench-history.jsonl
+
+fake-server flags:
+  -addr localhost:8080
+  -session fake      the session cookie to accept
+  -interval 0        turn down requests closer together than this, and wrong answers for as long
+                     (a minute when 0)
+
+inputs are cached in $AOC_CACHE, or advent-code/inputs in the user cache directory,
+and fetched from $AOC_URL with the $AOC_SESSION cookie, which answers are submitted
+with too; every attempt is kept in submissions.jsonl in the input cache
+
+report flags:
+  -threshold 10      percent a median may grow by before it counts as a regression
+  -last 5            saved runs shown per step
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "check":
+		err = checkCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "report":
+		err = reportCommand(os.Args[2:])
+	case "parity":
+		err = parityCommand(os.Args[2:])
+	case "fetch":
+		err = fetchCommand(os.Args[2:])
+	case "submit":
+		err = submitCommand(os.Args[2:])
+	case "fake-server":
+		err = fakeServerCommand(os.Args[2:])
+	case "new":
+		err = newCommand(os.Args[2:])
+	case "list":
+		err = listCommand(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Get rekt:", err)
+		os.Exit(1)
+	}
+}
+
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	visual := flags.Bool("visual", false, "render the visualisations of days that have one")
+	examples := flags.Bool("examples", false, "solve the days' example inputs before their data.txt")
+	input := flags.String("input", "", "solve a single day against this file, directory of files or - for stdin")
+	params := make(params)
+	flags.Var(params, "param", "override a day's `name=value` param, can be repeated")
+	flags.Parse(args)
+
+	if *visual {
+		os.Setenv("AOC_VISUAL", "1")
+	}
+
+	days, err := runner.Select(flags.Args())
+	if err != nil {
+		return err
+	}
+	opts := runner.Options{Examples: *examples, Params: params}
+	if *input != "" {
+		if opts.Inputs, err = runner.ReadInputs(*input); err != nil {
+			return err
+		}
+	}
+	return runner.Run(days, opts)
+}
+
+func checkCommand(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	update := flags.Bool("update", false, "record the current answers as the golden ones")
+	flags.Parse(args)
+
+	days, err := runner.Select(flags.Args())
+	if err != nil {
+		return err
+	}
+	return runner.Check(days, *update)
+}
+
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	iterations := flags.Int("n", 10, "timed runs of each step")
+	warmup := flags.Int("warmup", 2, "untimed runs of each step before the timed ones")
+	save := flags.Bool("save", false, "append the results to the bench history")
+	params := make(params)
+	flags.Var(params, "param", "override a day's `name=value` param, can be repeated")
+	flags.Parse(args)
+
+	if *iterations < 1 || *warmup < 0 {
+		return errors.New("bench needs -n of at least 1 and a -warmup of at least 0")
+	}
+
+	days, err := runner.Select(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	opts := bench.Options{Warmup: *warmup, Iterations: *iterations}
+	var results []bench.Result
+	for _, day := range days {
+		in, err := runner.ReadInput(day)
+		if err != nil {
+			return fmt.Errorf("%s: %w", day, err)
+		}
+		in.Params = params
+
+		for _, solution := range append([]*aoc.Day{day}, aoc.Variants(day.Year, day.Day)...) {
+			fmt.Fprintf(os.Stderr, "Benchmarking %s...\n", solution)
+			dayResults, err := bench.Day(solution, in, opts)
+			if err != nil {
+				return err
+			}
+			results = append(results, dayResults...)
+		}
+	}
+
+	fmt.Printf("\n%d runs after %d warm-up runs\n", *iterations, *warmup)
+	bench.Print(os.Stdout, results)
+
+	if *save {
+		root, err := runner.Root()
+		if err != nil {
+			return err
+		}
+		run := bench.NewRun(root, opts, results)
+		if err := bench.Save(filepath.Join(root, bench.HistoryFile), run); err != nil {
+			return err
+		}
+		fmt.Printf("\nSaved to %s at %s\n", bench.HistoryFile, run.Commit)
+	}
+	return nil
+}
+
+func reportCommand(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	threshold := flags.Float64("threshold", 10, "percent a median may grow by before it counts as a regression")
+	last := flags.Int("last", 5, "saved runs shown per step")
+	flags.Parse(args)
+
+	selection := flags.Args()
+	if len(selection) == 0 {
+		selection = []string{"all"}
+	}
+	days, err := runner.Select(selection)
+	if err != nil {
+		return err
+	}
+	selected := make(map[[2]int]bool)
+	for _, day := range days {
+		selected[[2]int{day.Year, day.Day}] = true
+	}
+
+	root, err := runner.Root()
+	if err != nil {
+		return err
+	}
+	runs, err := bench.LoadHistory(filepath.Join(root, bench.HistoryFile))
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("no saved runs in %s yet, bench with -save first", bench.HistoryFile)
+	}
+
+	latest := runs[len(runs)-1]
+	fmt.Printf("%d saved runs, latest at %s on %s (%s)\n\n",
+		len(runs), latest.Commit, latest.Machine.Host, latest.Time.Local().Format(time.DateTime))
+	trends := bench.Report(runs, bench.ReportOptions{
+		Threshold: *threshold,
+		Last:      *last,
+		Include:   func(year, day int) bool { return selected[[2]int{year, day}] },
+	})
+	if len(trends) == 0 {
+		return fmt.Errorf("no saved runs on %s measured these days", latest.Machine.Host)
+	}
+	bench.PrintReport(os.Stdout, trends)
+
+	if regressions := bench.Regressions(trends); regressions > 0 {
+		return fmt.Errorf("%d steps regressed by more than %g%%", regressions, *threshold)
+	}
+	return nil
+}
+
+func parityCommand(args []string) error {
+	flags := flag.NewFlagSet("parity", flag.ExitOnError)
+	runs := flags.Int("n", 1, "runs of each solution, timed as their mean")
+	flags.Parse(args)
+
+	if *runs < 1 {
+		return errors.New("parity needs -n of at least 1")
+	}
+
+	days, err := runner.Select(flags.Args())
+	if err != nil {
+		return err
+	}
+	root, err := runner.Root()
+	if err != nil {
+		return err
+	}
+	answers, err := golden.Load(filepath.Join(root, golden.FileName))
+	if err != nil {
+		return err
+	}
+
+	// builds go to the temp dir and stay there, so cargo only fetches and
+	// compiles the crates once
+	checker := &parity.Checker{
+		Root:    root,
+		Work:    filepath.Join(os.TempDir(), "advent-code-parity"),
+		Runs:    *runs,
+		Answers: answers,
+	}
+
+	var results []parity.Result
+	for _, day := range days {
+		if !checker.Ported(day) {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Checking %s...\n", day)
+		results = append(results, checker.Day(day)...)
+	}
+	if len(results) == 0 {
+		return parity.ErrNoPorts
+	}
+
+	fmt.Println()
+	parity.Print(os.Stdout, results)
+	if failed := parity.Failed(results); failed > 0 {
+		return fmt.Errorf("%d solutions failed or disagree", failed)
+	}
+	return nil
+}
+
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	importData := flags.Bool("import", false, "cache the committed data.txt files instead of fetching")
+	flags.Parse(args)
+
+	days, err := runner.Select(flags.Args())
+	if err != nil {
+		return err
+	}
+	store, err := inputs.Default()
+	if err != nil {
+		return err
+	}
+	root, err := runner.Root()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, day := range days {
+		if _, ok, err := store.Cached(day.Year, day.Day); err != nil || ok {
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ %s: cached\n", day)
+			continue
+		}
+
+		if *importData {
+			data, err := os.ReadFile(filepath.Join(root, day.Dir(), "data.txt"))
+			if err == nil {
+				err = store.Put(day.Year, day.Day, data)
+			}
+			if err != nil {
+				failed++
+				fmt.Printf("❌ %s: %v\n", day, err)
+				continue
+			}
+			fmt.Printf("📥 %s: imported\n", day)
+			continue
+		}
+
+		if _, err := store.Get(day.Year, day.Day); err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", day, err)
+			continue
+		}
+		fmt.Printf("📥 %s: fetched\n", day)
+	}
+
+	fmt.Printf("Inputs are in %s\n", store.Dir)
+	if failed > 0 {
+		return fmt.Errorf("%d inputs missing", failed)
+	}
+	return nil
+}
+
+func submitCommand(args []string) error {
+	if len(args) < 3 || len(args) > 4 {
+		return errors.New("expected <year> <day> <part> [answer]")
+	}
+	var year, day, part int
+	for i, n := range []*int{&year, &day, &part} {
+		var err error
+		if *n, err = strconv.Atoi(args[i]); err != nil {
+			return fmt.Errorf("invalid number %q", args[i])
+		}
+	}
+	if part != 1 && part != 2 {
+		return fmt.Errorf("no part %d, there are only parts 1 and 2", part)
+	}
+
+	var answer string
+	if len(args) == 4 {
+		answer = strings.TrimSpace(args[3])
+	} else {
+		solution, ok := aoc.Lookup(year, day)
+		if !ok {
+			return fmt.Errorf("no registered %d day %d to solve", year, day)
+		}
+		in, err := runner.ReadInput(solution)
+		if err != nil {
+			return err
+		}
+		got, err := runner.Solve(solution, part, in)
+		if err != nil {
+			return err
+		}
+		answer = got.String()
+	}
+	if answer == "" || strings.ContainsAny(answer, " \t\n") {
+		return fmt.Errorf("%q doesn't look like an answer", answer)
+	}
+
+	store, err := inputs.Default()
+	if err != nil {
+		return err
+	}
+	if store.Fetcher == nil {
+		return fmt.Errorf("no session to submit with, set %s", inputs.SessionEnv)
+	}
+	if err := os.MkdirAll(store.Dir, 0o755); err != nil {
+		return err
+	}
+	history, err := submit.LoadHistory(filepath.Join(store.Dir, submit.HistoryFile))
+	if err != nil {
+		return err
+	}
+	if err := history.Check(year, day, part, answer, time.Now()); err != nil {
+		return fmt.Errorf("not submitting %s: %w", answer, err)
+	}
+
+	session, err := inputs.Session()
+	if err != nil {
+		return err
+	}
+	baseURL := os.Getenv(inputs.URLEnv)
+	if baseURL == "" {
+		baseURL = inputs.DefaultURL
+	}
+	client := &submit.Client{BaseURL: baseURL, Session: session}
+
+	fmt.Printf("Submitting %s for %d day %d part %d...\n", answer, year, day, part)
+	verdict, err := client.Submit(year, day, part, answer)
+	if err != nil {
+		return err
+	}
+	if err := history.Record(submit.Attempt{
+		Time:    time.Now().UTC().Truncate(time.Second),
+		Year:    year,
+		Day:     day,
+		Part:    part,
+		Answer:  answer,
+		Outcome: verdict.Outcome,
+		Hint:    verdict.Hint,
+		Wait:    verdict.Wait,
+	}); err != nil {
+		return err
+	}
+
+	switch verdict.Outcome {
+	case submit.Correct:
+		fmt.Println("⭐️ That's the right answer")
+	case submit.Solved:
+		fmt.Println("✅ Already solved")
+	case submit.Unknown:
+		return fmt.Errorf("can't make sense of the reply: %s", verdict.Message)
+	default:
+		return fmt.Errorf("%s", verdict)
+	}
+	return nil
+}
+
+func fakeServerCommand(args []string) error {
+	flags := flag.NewFlagSet("fake-server", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	session := flags.String("session", "fake", "the session cookie to accept")
+	interval := flags.Duration("interval", 0, "turn down requests closer together than this, and wrong answers for as long")
+	flags.Parse(args)
+
+	root, err := runner.Root()
+	if err != nil {
+		return err
+	}
+	answers, err := golden.Load(filepath.Join(root, golden.FileName))
+	if err != nil {
+		return err
+	}
+
+	inputServer := &inputs.FakeServer{
+		Session:     *session,
+		MinInterval: *interval,
+		Input: func(year, day int) ([]byte, bool) {
+			solution, ok := aoc.Lookup(year, day)
+			if !ok {
+				return nil, false
+			}
+			data, err := os.ReadFile(filepath.Join(root, solution.Dir(), "data.txt"))
+			return data, err == nil
+		},
+	}
+
+	answerServer := &submit.MockServer{
+		Session:  *session,
+		Cooldown: *interval,
+		Answer: func(year, day, part int) (string, bool) {
+			answer, ok := answers[golden.Key{Year: year, Day: day, Part: part}]
+			return answer.String(), ok
+		},
+	}
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/answer") {
+			answerServer.ServeHTTP(w, r)
+			return
+		}
+		inputServer.ServeHTTP(w, r)
+	})
+
+	fmt.Printf("Serving the committed inputs and answers on http://%s, use it with\n", *addr)
+	fmt.Printf("  %s=http://%s %s=%s go run ./cmd/aoc fetch all\n", inputs.URLEnv, *addr, inputs.SessionEnv, *session)
+	return http.ListenAndServe(*addr, server)
+}
+
+func newCommand(args []string) error {
+	if len(args) != 2 {
+		return errors.New("expected <year> <day>")
+	}
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid year %q", args[0])
+	}
+	day, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", args[1])
+	}
+	if existing, ok := aoc.Lookup(year, day); ok {
+		return fmt.Errorf("%s already exists in %s", existing, existing.Dir())
+	}
+
+	root, err := runner.Root()
+	if err != nil {
+		return err
+	}
+	written, err := scaffold.New(root, year, day)
+	for _, name := range written {
+		fmt.Println("📝", name)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nPaste the example into examples/example.txt and main_test.go, then\n  go run ./cmd/aoc run -examples %d %d\n", year, day)
+	return nil
+}
+
+func listCommand(args []string) error {
+	if len(args) == 0 {
+		args = []string{"all"}
+	}
+	days, err := runner.Select(args)
+	if err != nil {
+		return err
+	}
+	for _, day := range days {
+		fmt.Printf("%-12s %s\n", day, day.Dir())
+		for _, variant := range aoc.Variants(day.Year, day.Day) {
+			fmt.Printf("%-12s %s\n", "  "+variant.Variant, day.Dir())
+		}
+	}
+	return nil
+}
+
+// params collects repeated -param name=value flags.
+type params map[string]string
+
+func (p params) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, value := range p {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (p params) Set(flag string) error {
+	name, value, ok := strings.Cut(flag, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", flag)
+	}
+	p[name] = value
+	return nil
+}
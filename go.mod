@@ -1,8 +1,20 @@
-module main
+module advent-code
 
 go 1.23.3
 
+require (
+	aoc v0.0.0
+	aoc2024 v0.0.0
+	aoc2025 v0.0.0
+)
+
 require (
 	github.com/eiannone/keyboard v0.0.0-20220611211555-0d226195f203 // indirect
 	golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
 )
+
+replace (
+	aoc => ./aoc
+	aoc2024 => ./2024
+	aoc2025 => ./2025
+)
@@ -0,0 +1,211 @@
+// Package bench times a day's parse step and parts in-process, without the
+// build and process start-up noise of timing whole binaries.
+package bench
+
+import (
+	"aoc"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Options tune how many times each step runs.
+type Options struct {
+	Warmup     int
+	Iterations int
+}
+
+// Stats summarise the timed runs of one step.
+type Stats struct {
+	Runs        int           `json:"runs"`
+	Mean        time.Duration `json:"mean"`
+	Median      time.Duration `json:"median"`
+	Stddev      time.Duration `json:"stddev"`
+	Min         time.Duration `json:"min"`
+	AllocsPerOp uint64        `json:"allocsPerOp"`
+	BytesPerOp  uint64        `json:"bytesPerOp"`
+}
+
+// Result is the measurement of one step ("parse", "part1" or "part2") of a
+// day or one of its variants.
+type Result struct {
+	Year    int    `json:"year"`
+	Day     int    `json:"day"`
+	Variant string `json:"variant,omitempty"`
+	Step    string `json:"step"`
+	Stats   Stats  `json:"stats"`
+}
+
+// Day measures the parse step and each part of a day against the input.
+// Parts are handed freshly parsed data on every run, outside of the timing.
+// It points os.Stdout at the null device while it runs, so it is not safe to
+// call concurrently or alongside anything else that writes to os.Stdout.
+func Day(day *aoc.Day, in aoc.Input, opts Options) ([]Result, error) {
+	result := func(step string, stats Stats) Result {
+		return Result{Year: day.Year, Day: day.Day, Variant: day.Variant, Step: step, Stats: stats}
+	}
+
+	var results []Result
+	parse, err := measure(opts, func() (func() error, error) {
+		return func() error {
+			_, err := day.Parse(in)
+			return err
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s parse: %w", day, err)
+	}
+	results = append(results, result("parse", parse))
+
+	for part := 1; part <= 2; part++ {
+		if !day.HasPart(part) {
+			continue
+		}
+		stats, err := measure(opts, func() (func() error, error) {
+			data, err := day.Parse(in)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				_, err := day.Solve(part, data)
+				return err
+			}, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s part %d: %w", day, part, err)
+		}
+		results = append(results, result(fmt.Sprintf("part%d", part), stats))
+	}
+	return results, nil
+}
+
+// measure runs the step returned by setup, timing only the step itself.
+// Whatever the step prints is thrown away so it does not skew the timings,
+// by swapping os.Stdout for the whole process until it returns.
+func measure(opts Options, setup func() (func() error, error)) (Stats, error) {
+	stdout := os.Stdout
+	if devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+		os.Stdout = devNull
+		defer devNull.Close()
+	}
+	defer func() { os.Stdout = stdout }()
+
+	runs := make([]time.Duration, 0, opts.Iterations)
+	var allocs, bytes uint64
+	var before, after runtime.MemStats
+
+	for i := 0; i < opts.Warmup+opts.Iterations; i++ {
+		step, err := setup()
+		if err != nil {
+			return Stats{}, err
+		}
+
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err = step()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			return Stats{}, err
+		}
+
+		if i < opts.Warmup {
+			continue
+		}
+		runs = append(runs, elapsed)
+		allocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+	}
+
+	stats := summarise(runs)
+	if n := uint64(len(runs)); n > 0 {
+		stats.AllocsPerOp = allocs / n
+		stats.BytesPerOp = bytes / n
+	}
+	return stats, nil
+}
+
+func summarise(runs []time.Duration) Stats {
+	if len(runs) == 0 {
+		return Stats{}
+	}
+
+	sorted := append([]time.Duration(nil), runs...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+
+	var total time.Duration
+	for _, run := range sorted {
+		total += run
+	}
+	mean := total / time.Duration(len(sorted))
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	var variance float64
+	for _, run := range sorted {
+		diff := float64(run - mean)
+		variance += diff * diff
+	}
+	variance /= float64(len(sorted))
+
+	return Stats{
+		Runs:   len(sorted),
+		Mean:   mean,
+		Median: median,
+		Stddev: time.Duration(math.Sqrt(variance)),
+		Min:    sorted[0],
+	}
+}
+
+// Print lays the results out as a table. Variants are compared with the main
+// solution's mean for the same step.
+func Print(w io.Writer, results []Result) {
+	type stepKey struct {
+		year, day int
+		step      string
+	}
+	mainMean := make(map[stepKey]time.Duration)
+	for _, r := range results {
+		if r.Variant == "" {
+			mainMean[stepKey{r.Year, r.Day, r.Step}] = r.Stats.Mean
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "day\tvariant\tstep\tmean\tmedian\tstddev\tmin\tallocs/op\tB/op\tvs main\t")
+	for _, r := range results {
+		variant := r.Variant
+		if variant == "" {
+			variant = "main"
+		}
+
+		comparison := ""
+		if base, ok := mainMean[stepKey{r.Year, r.Day, r.Step}]; ok && r.Variant != "" && r.Stats.Mean > 0 {
+			comparison = fmt.Sprintf("%.2fx", float64(base)/float64(r.Stats.Mean))
+		}
+
+		fmt.Fprintf(tw, "%d/%d\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t\n",
+			r.Year, r.Day, variant, r.Step,
+			round(r.Stats.Mean), round(r.Stats.Median), round(r.Stats.Stddev), round(r.Stats.Min),
+			r.Stats.AllocsPerOp, r.Stats.BytesPerOp, comparison)
+	}
+	tw.Flush()
+}
+
+// round keeps three significant digits, which is as precise as timings get.
+func round(d time.Duration) time.Duration {
+	for unit := time.Duration(1); unit < time.Hour; unit *= 10 {
+		if d < unit*1000 {
+			return d.Round(unit)
+		}
+	}
+	return d
+}
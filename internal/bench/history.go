@@ -0,0 +1,286 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// HistoryFile is where saved runs pile up, at the repository root. It's local
+// to the machine and not committed.
+const HistoryFile = "bench-history.jsonl"
+
+// Run is one saved invocation of bench. The history file holds a run per line.
+type Run struct {
+	Time       time.Time `json:"time"`
+	Commit     string    `json:"commit"`
+	Machine    Machine   `json:"machine"`
+	Iterations int       `json:"iterations"`
+	Results    []Result  `json:"results"`
+}
+
+// Machine describes where a run happened, timings only compare on the same one.
+type Machine struct {
+	Host string `json:"host"`
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	CPU  string `json:"cpu,omitempty"`
+	CPUs int    `json:"cpus"`
+	Go   string `json:"go"`
+}
+
+// NewRun stamps results with the time, the commit checked out in root and the
+// machine they were measured on.
+func NewRun(root string, opts Options, results []Result) Run {
+	return Run{
+		Time:       time.Now().UTC().Truncate(time.Second),
+		Commit:     commit(root),
+		Machine:    CurrentMachine(),
+		Iterations: opts.Iterations,
+		Results:    results,
+	}
+}
+
+// CurrentMachine describes the machine this runs on.
+func CurrentMachine() Machine {
+	host, _ := os.Hostname()
+	return Machine{
+		Host: host,
+		OS:   runtime.GOOS,
+		Arch: runtime.GOARCH,
+		CPU:  cpuModel(),
+		CPUs: runtime.NumCPU(),
+		Go:   runtime.Version(),
+	}
+}
+
+// commit is the short hash of HEAD, suffixed with "-dirty" when the tree has
+// changes, or "unknown" outside of git.
+func commit(root string) string {
+	head, err := exec.Command("git", "-C", root, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	hash := strings.TrimSpace(string(head))
+	if status, err := exec.Command("git", "-C", root, "status", "--porcelain").Output(); err == nil && len(status) > 0 {
+		hash += "-dirty"
+	}
+	return hash
+}
+
+// cpuModel reads the CPU name from /proc/cpuinfo, where there is one.
+func cpuModel() string {
+	info, err := os.ReadFile("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(info), "\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(name) == "model name" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// Save appends a run to the history file, creating it if needed.
+func Save(path string, run Run) error {
+	line, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadHistory reads every saved run, oldest first. A missing file is an empty
+// history.
+func LoadHistory(path string) ([]Run, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var runs []Run
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(runs, func(a, b int) bool { return runs[a].Time.Before(runs[b].Time) })
+	return runs, nil
+}
+
+// ReportOptions tune what Report considers a regression.
+type ReportOptions struct {
+	// Threshold is how much slower, in percent, the latest median may be than
+	// the baseline's before it's flagged.
+	Threshold float64
+	// Last is how many runs the trend column shows.
+	Last int
+	// Include picks the days to report on, all of them when nil.
+	Include func(year, day int) bool
+}
+
+// Trend is the history of one step of a day on one machine.
+type Trend struct {
+	Year    int
+	Day     int
+	Variant string
+	Step    string
+	// Medians of the runs that measured the step, oldest first.
+	Medians []time.Duration
+	// Commits the medians were measured at.
+	Commits []string
+	// Change of the latest median against the one before it, the baseline,
+	// in percent.
+	Change    float64
+	Regressed bool
+}
+
+type trendKey struct {
+	year, day     int
+	variant, step string
+}
+
+// Report follows each step the latest run measured through the runs made on
+// the same machine, and flags the steps whose latest median regressed against
+// the previous run that measured them. Steps the latest run didn't measure
+// have no latest median and are left out.
+func Report(runs []Run, opts ReportOptions) []Trend {
+	if len(runs) == 0 {
+		return nil
+	}
+	machine := runs[len(runs)-1].Machine
+	measured := make(map[trendKey]bool)
+	for _, r := range runs[len(runs)-1].Results {
+		measured[trendKey{r.Year, r.Day, r.Variant, r.Step}] = true
+	}
+
+	trends := make(map[trendKey]*Trend)
+	var order []trendKey
+	for _, run := range runs {
+		if run.Machine != machine {
+			continue
+		}
+		for _, r := range run.Results {
+			if opts.Include != nil && !opts.Include(r.Year, r.Day) {
+				continue
+			}
+			key := trendKey{r.Year, r.Day, r.Variant, r.Step}
+			if !measured[key] {
+				continue
+			}
+			trend, ok := trends[key]
+			if !ok {
+				trend = &Trend{Year: r.Year, Day: r.Day, Variant: r.Variant, Step: r.Step}
+				trends[key] = trend
+				order = append(order, key)
+			}
+			trend.Medians = append(trend.Medians, r.Stats.Median)
+			trend.Commits = append(trend.Commits, run.Commit)
+		}
+	}
+
+	sort.Slice(order, func(a, b int) bool {
+		x, y := order[a], order[b]
+		if x.year != y.year {
+			return x.year < y.year
+		}
+		if x.day != y.day {
+			return x.day < y.day
+		}
+		if x.variant != y.variant {
+			return x.variant < y.variant
+		}
+		return x.step < y.step
+	})
+
+	report := make([]Trend, 0, len(order))
+	for _, key := range order {
+		trend := trends[key]
+		if n := len(trend.Medians); n >= 2 && trend.Medians[n-2] > 0 {
+			baseline, latest := trend.Medians[n-2], trend.Medians[n-1]
+			trend.Change = 100 * (float64(latest) - float64(baseline)) / float64(baseline)
+			trend.Regressed = trend.Change > opts.Threshold
+		}
+		if opts.Last > 0 && len(trend.Medians) > opts.Last {
+			trend.Medians = trend.Medians[len(trend.Medians)-opts.Last:]
+			trend.Commits = trend.Commits[len(trend.Commits)-opts.Last:]
+		}
+		report = append(report, *trend)
+	}
+	return report
+}
+
+// PrintReport writes the trends as a table, regressions marked.
+func PrintReport(w io.Writer, trends []Trend) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tvariant\tstep\tmedians, oldest first\tchange\tsince\t\t")
+	for _, t := range trends {
+		variant := t.Variant
+		if variant == "" {
+			variant = "main"
+		}
+
+		medians := make([]string, len(t.Medians))
+		for i, median := range t.Medians {
+			medians[i] = round(median).String()
+		}
+
+		change, since, flag := "", "", ""
+		if n := len(t.Medians); n >= 2 {
+			change = fmt.Sprintf("%+.1f%%", t.Change)
+			since = t.Commits[n-2]
+		}
+		if t.Regressed {
+			flag = "⚠️  regressed"
+		}
+
+		fmt.Fprintf(tw, "%d/%d\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			t.Year, t.Day, variant, t.Step, strings.Join(medians, " → "), change, since, flag)
+	}
+	tw.Flush()
+}
+
+// Regressions counts the flagged trends.
+func Regressions(trends []Trend) int {
+	regressions := 0
+	for _, t := range trends {
+		if t.Regressed {
+			regressions++
+		}
+	}
+	return regressions
+}
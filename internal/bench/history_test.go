@@ -0,0 +1,153 @@
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	here  = Machine{Host: "here", OS: "linux", Arch: "amd64", CPUs: 8, Go: "go1.23.3"}
+	there = Machine{Host: "there", OS: "linux", Arch: "amd64", CPUs: 8, Go: "go1.23.3"}
+)
+
+// run is a run on machine at commit measuring "year/day/step" steps, the
+// step's median in milliseconds.
+func run(machine Machine, commit string, medians map[string]int) Run {
+	r := Run{Commit: commit, Machine: machine, Iterations: 5}
+	for name, ms := range medians {
+		var year, day int
+		var step string
+		if _, err := fmt.Sscanf(strings.ReplaceAll(name, "/", " "), "%d %d %s", &year, &day, &step); err != nil {
+			panic(err)
+		}
+		r.Results = append(r.Results, Result{Year: year, Day: day, Step: step, Stats: Stats{Median: time.Duration(ms) * time.Millisecond}})
+	}
+	return r
+}
+
+func TestReport(t *testing.T) {
+	runs := []Run{
+		run(here, "a", map[string]int{"2024/1/part1": 10, "2024/1/part2": 100, "2024/2/part1": 50}),
+		run(there, "b", map[string]int{"2024/1/part1": 1, "2024/3/part1": 1}),
+		run(here, "c", map[string]int{"2024/1/part1": 12, "2024/1/part2": 100, "2024/2/part1": 500}),
+		run(here, "d", map[string]int{"2024/1/part1": 11, "2024/1/part2": 130, "2025/1/part1": 7}),
+	}
+
+	type row struct {
+		step      string
+		medians   []time.Duration
+		commits   []string
+		regressed bool
+	}
+	ms := func(values ...int) []time.Duration {
+		durations := make([]time.Duration, len(values))
+		for i, v := range values {
+			durations[i] = time.Duration(v) * time.Millisecond
+		}
+		return durations
+	}
+	tests := []struct {
+		name string
+		opts ReportOptions
+		want []row
+	}{
+		{
+			// 2024/2 was last measured by c, and 2024/3 only on the other machine
+			name: "steps of the latest run",
+			opts: ReportOptions{Threshold: 10},
+			want: []row{
+				{"2024/1/part1", ms(10, 12, 11), []string{"a", "c", "d"}, false},
+				{"2024/1/part2", ms(100, 100, 130), []string{"a", "c", "d"}, true},
+				{"2025/1/part1", ms(7), []string{"d"}, false},
+			},
+		},
+		{
+			name: "higher threshold",
+			opts: ReportOptions{Threshold: 50},
+			want: []row{
+				{"2024/1/part1", ms(10, 12, 11), []string{"a", "c", "d"}, false},
+				{"2024/1/part2", ms(100, 100, 130), []string{"a", "c", "d"}, false},
+				{"2025/1/part1", ms(7), []string{"d"}, false},
+			},
+		},
+		{
+			name: "last two of 2024",
+			opts: ReportOptions{Threshold: 10, Last: 2, Include: func(year, day int) bool { return year == 2024 }},
+			want: []row{
+				{"2024/1/part1", ms(12, 11), []string{"c", "d"}, false},
+				{"2024/1/part2", ms(100, 130), []string{"c", "d"}, true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Report(runs, tt.opts)
+			if len(report) != len(tt.want) {
+				t.Fatalf("%d trends, want %d: %+v", len(report), len(tt.want), report)
+			}
+			for i, trend := range report {
+				want := tt.want[i]
+				step := fmt.Sprintf("%d/%d/%s", trend.Year, trend.Day, trend.Step)
+				if step != want.step || !slices.Equal(trend.Medians, want.medians) ||
+					!slices.Equal(trend.Commits, want.commits) || trend.Regressed != want.regressed {
+					t.Errorf("trend %d = %s %v %v regressed %v, want %+v", i, step, trend.Medians, trend.Commits, trend.Regressed, want)
+				}
+			}
+		})
+	}
+
+	if report := Report(nil, ReportOptions{}); report != nil {
+		t.Errorf("Report of no runs = %v", report)
+	}
+	report := Report(runs, ReportOptions{Threshold: 10})
+	if n := Regressions(report); n != 1 {
+		t.Errorf("%d regressions, want 1", n)
+	}
+	if change := report[1].Change; change != 30 {
+		t.Errorf("change %.1f%%, want +30%%", change)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HistoryFile)
+	if runs, err := LoadHistory(path); err != nil || runs != nil {
+		t.Fatalf("LoadHistory of a missing file = %v, %v", runs, err)
+	}
+
+	start := time.Date(2025, 12, 1, 5, 0, 0, 0, time.UTC)
+	later := run(here, "later", map[string]int{"2024/1/part1": 2})
+	later.Time = start.Add(time.Hour)
+	earlier := run(here, "earlier", map[string]int{"2024/1/part1": 1})
+	earlier.Time = start
+	for _, r := range []Run{later, earlier} {
+		if err := Save(path, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].Commit != "earlier" || runs[1].Commit != "later" {
+		t.Fatalf("runs %+v, want earlier then later", runs)
+	}
+	if runs[1].Machine != here || runs[1].Results[0].Stats.Median != 2*time.Millisecond {
+		t.Errorf("run didn't round trip: %+v", runs[1])
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("\n{not json\n")
+	file.Close()
+	if _, err := LoadHistory(path); err == nil || !strings.Contains(err.Error(), HistoryFile+":4:") {
+		t.Errorf("error %v, want one at line 4", err)
+	}
+}
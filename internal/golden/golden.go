@@ -0,0 +1,95 @@
+// Package golden keeps the known-correct answer of every solved part, so a
+// refactor that changes what a day solves to gets caught.
+//
+// Answers live in a plain text file, one "year day part answer" line each:
+//
+//	2024 17 1 1,7,6,5,1,0,5,0,7
+//	2025 1 2 6860
+package golden
+
+import (
+	"aoc"
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// FileName is the answers file at the repository root.
+const FileName = "answers.txt"
+
+// Key identifies a single part of a day.
+type Key struct {
+	Year, Day, Part int
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%d day %d part %d", k.Year, k.Day, k.Part)
+}
+
+// Answers maps each part to its known-correct answer.
+type Answers map[Key]aoc.Answer
+
+// Load reads an answers file. A missing file is just an empty set of answers.
+func Load(path string) (Answers, error) {
+	answers := make(Answers)
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		row := strings.TrimSpace(scanner.Text())
+		if row == "" || strings.HasPrefix(row, "#") {
+			continue
+		}
+
+		var key Key
+		var answer string
+		if _, err := fmt.Sscanf(row, "%d %d %d %s", &key.Year, &key.Day, &key.Part, &answer); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if _, exists := answers[key]; exists {
+			return nil, fmt.Errorf("%s:%d: %s listed twice", path, line, key)
+		}
+		answers[key] = aoc.String(answer)
+	}
+	return answers, scanner.Err()
+}
+
+// Save writes the answers sorted by year, day and part.
+func (answers Answers) Save(path string) error {
+	keys := make([]Key, 0, len(answers))
+	for key := range answers {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if keys[a].Year != keys[b].Year {
+			return keys[a].Year < keys[b].Year
+		}
+		if keys[a].Day != keys[b].Day {
+			return keys[a].Day < keys[b].Day
+		}
+		return keys[a].Part < keys[b].Part
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# year day part answer - regenerate with `go run ./cmd/aoc check -update all`\n")
+	for _, key := range keys {
+		answer := answers[key].String()
+		if answer == "" || strings.ContainsAny(answer, " \t\r\n") {
+			return fmt.Errorf("%s: answer %q cannot be stored", key, answer)
+		}
+		fmt.Fprintf(&sb, "%d %d %d %s\n", key.Year, key.Day, key.Part, answer)
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
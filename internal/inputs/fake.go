@@ -0,0 +1,68 @@
+package inputs
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FakeServer stands in for the puzzle site, so fetching can be tried without
+// the network or a real session. It serves /<year>/day/<day>/input like the
+// real one, answers like it without a session cookie, and turns down requests
+// coming quicker than MinInterval.
+type FakeServer struct {
+	// Session is the only cookie value accepted.
+	Session string
+	// Input returns the input of a day, false for days it has none of.
+	Input func(year, day int) ([]byte, bool)
+	// MinInterval between two requests, unlimited when zero.
+	MinInterval time.Duration
+
+	mu       sync.Mutex
+	last     time.Time
+	requests int
+}
+
+// Requests counts the requests served so far, turned down ones included.
+func (s *FakeServer) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	tooSoon := s.MinInterval > 0 && time.Since(s.last) < s.MinInterval
+	if !tooSoon {
+		s.last = time.Now()
+	}
+	s.mu.Unlock()
+
+	var year, day int
+	if _, err := fmt.Sscanf(r.URL.Path, "/%d/day/%d/input", &year, &day); err != nil || r.Method != http.MethodGet {
+		http.NotFound(w, r)
+		return
+	}
+
+	if tooSoon {
+		w.Header().Set("Retry-After", fmt.Sprint(int(s.MinInterval.Seconds()+1)))
+		http.Error(w, "Slow down.", http.StatusTooManyRequests)
+		return
+	}
+
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != s.Session {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return
+	}
+
+	input, ok := s.Input(year, day)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(input)
+}
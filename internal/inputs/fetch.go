@@ -0,0 +1,111 @@
+package inputs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// userAgent identifies the fetcher, as the puzzle site asks automated tools to.
+const userAgent = "advent-code input store (Go net/http)"
+
+// DefaultInterval is the least time between two requests.
+const DefaultInterval = 3 * time.Second
+
+// maxAttempts bounds the retries of a rate-limited request.
+const maxAttempts = 3
+
+// HTTPFetcher downloads inputs from <BaseURL>/<year>/day/<day>/input with
+// the session cookie, spacing its requests by Interval and backing off when
+// the server says it's asked too often.
+type HTTPFetcher struct {
+	BaseURL string
+	Session string
+	// Interval is the least time between two requests, DefaultInterval when zero.
+	Interval time.Duration
+	// Client defaults to one with a 30 second timeout.
+	Client *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Fetch downloads the input of a day.
+func (f *HTTPFetcher) Fetch(year, day int) ([]byte, error) {
+	if !released(year, day, time.Now()) {
+		return nil, fmt.Errorf("%d day %d isn't out yet", year, day)
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(f.BaseURL, "/"), year, day)
+	for attempt := 1; ; attempt++ {
+		data, retryAfter, err := f.get(url)
+		if retryAfter == 0 || attempt == maxAttempts {
+			return data, err
+		}
+		time.Sleep(retryAfter)
+	}
+}
+
+// get makes a single request. A positive retryAfter means the server turned
+// it down for now.
+func (f *HTTPFetcher) get(url string) (data []byte, retryAfter time.Duration, err error) {
+	f.wait()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+
+	client := f.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, 0, nil
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		retryAfter = f.interval()
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return nil, retryAfter, fmt.Errorf("GET %s: %s, retry after %s", url, resp.Status, retryAfter)
+	default:
+		message, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+		return nil, 0, fmt.Errorf("GET %s: %s: %s", url, resp.Status, message)
+	}
+}
+
+// wait holds the request back until Interval has passed since the last one.
+func (f *HTTPFetcher) wait() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if next := f.last.Add(f.interval()); time.Now().Before(next) {
+		time.Sleep(time.Until(next))
+	}
+	f.last = time.Now()
+}
+
+func (f *HTTPFetcher) interval() time.Duration {
+	if f.Interval > 0 {
+		return f.Interval
+	}
+	return DefaultInterval
+}
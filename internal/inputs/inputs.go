@@ -0,0 +1,157 @@
+// Package inputs keeps puzzle inputs in a cache outside the repository and
+// fetches the missing ones. A fetched input never changes, so it's cached for
+// good and the puzzle site is asked for each at most once.
+package inputs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Environment variables configuring the default store.
+const (
+	// CacheEnv overrides where inputs are cached.
+	CacheEnv = "AOC_CACHE"
+	// SessionEnv holds the session cookie of the account inputs are fetched for.
+	SessionEnv = "AOC_SESSION"
+	// URLEnv points the fetcher at another server, like the fake one.
+	URLEnv = "AOC_URL"
+)
+
+// DefaultURL is the puzzle site.
+const DefaultURL = "https://adventofcode.com"
+
+// ErrNotCached is returned for inputs that aren't cached when there is no
+// session to fetch them with.
+var ErrNotCached = errors.New("input not cached and no session to fetch it, set " + SessionEnv)
+
+// Fetcher gets an input from wherever inputs come from.
+type Fetcher interface {
+	Fetch(year, day int) ([]byte, error)
+}
+
+// Store is a directory of cached inputs, one <year>/<day>.txt each, filled by
+// the fetcher when one is set.
+type Store struct {
+	Dir     string
+	Fetcher Fetcher
+}
+
+// Default is the store in the user's cache directory, or AOC_CACHE, fetching
+// from AOC_URL with the AOC_SESSION token, or the session file in the user's
+// config directory. Without a session it only reads the cache.
+func Default() (*Store, error) {
+	dir := os.Getenv(CacheEnv)
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(cache, "advent-code", "inputs")
+	}
+	store := &Store{Dir: dir}
+
+	session, err := Session()
+	if err != nil {
+		return nil, err
+	}
+	if session != "" {
+		url := os.Getenv(URLEnv)
+		if url == "" {
+			url = DefaultURL
+		}
+		store.Fetcher = &HTTPFetcher{BaseURL: url, Session: session}
+	}
+	return store, nil
+}
+
+// Session is the AOC_SESSION token, falling back to the contents of
+// advent-code/session in the user's config directory. No session at all is
+// an empty string.
+func Session() (string, error) {
+	if session := os.Getenv(SessionEnv); session != "" {
+		return session, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", nil
+	}
+	data, err := os.ReadFile(filepath.Join(config, "advent-code", "session"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Path is where the input of a day is cached.
+func (s *Store) Path(year, day int) string {
+	return filepath.Join(s.Dir, fmt.Sprint(year), fmt.Sprintf("%d.txt", day))
+}
+
+// Cached returns the input of a day if it's in the cache.
+func (s *Store) Cached(year, day int) ([]byte, bool, error) {
+	data, err := os.ReadFile(s.Path(year, day))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Get returns the input of a day from the cache, fetching and caching it
+// first if it isn't there yet.
+func (s *Store) Get(year, day int) ([]byte, error) {
+	data, ok, err := s.Cached(year, day)
+	if err != nil || ok {
+		return data, err
+	}
+	if s.Fetcher == nil {
+		return nil, ErrNotCached
+	}
+
+	data, err = s.Fetcher.Fetch(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return data, s.Put(year, day, data)
+}
+
+// Put caches the input of a day. The file is swapped in whole, so a failed
+// write never leaves a truncated input behind.
+func (s *Store) Put(year, day int, data []byte) error {
+	path := s.Path(year, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".input-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// released reports whether the puzzle of a day is out, at midnight EST on its
+// day of December.
+func released(year, day int, now time.Time) bool {
+	est := time.FixedZone("EST", -5*60*60)
+	return !now.Before(time.Date(year, time.December, day, 0, 0, 0, 0, est))
+}
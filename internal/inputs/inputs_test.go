@@ -0,0 +1,139 @@
+package inputs
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newFake(t *testing.T, minInterval time.Duration) (*FakeServer, *httptest.Server) {
+	t.Helper()
+	fake := &FakeServer{
+		Session: "secret",
+		Input: func(year, day int) ([]byte, bool) {
+			if day > 25 {
+				return nil, false
+			}
+			return []byte(strings.Repeat("x", day) + "\n"), true
+		},
+		MinInterval: minInterval,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func TestStoreCachesFetchedInputs(t *testing.T) {
+	fake, server := newFake(t, 0)
+	store := &Store{
+		Dir:     t.TempDir(),
+		Fetcher: &HTTPFetcher{BaseURL: server.URL, Session: "secret", Interval: time.Millisecond},
+	}
+
+	for i := range 2 {
+		data, err := store.Get(2015, 3)
+		if err != nil {
+			t.Fatalf("get %d: %v", i+1, err)
+		}
+		if want := []byte("xxx\n"); !bytes.Equal(data, want) {
+			t.Fatalf("get %d = %q, want %q", i+1, data, want)
+		}
+	}
+	if got := fake.Requests(); got != 1 {
+		t.Errorf("the server got %d requests, want 1 with the second get served from the cache", got)
+	}
+
+	data, ok, err := store.Cached(2015, 3)
+	if err != nil || !ok || string(data) != "xxx\n" {
+		t.Errorf("Cached(2015, 3) = %q, %v, %v, want the fetched input", data, ok, err)
+	}
+}
+
+func TestStoreWithoutFetcher(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+	if _, err := store.Get(2015, 1); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("Get without a fetcher: %v, want ErrNotCached", err)
+	}
+
+	if err := store.Put(2015, 1, []byte("cached\n")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := store.Get(2015, 1)
+	if err != nil || string(data) != "cached\n" {
+		t.Errorf("Get after Put = %q, %v, want the cached input", data, err)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		year    int
+		day     int
+		want    string
+	}{
+		{"no session", "", 2015, 1, "log in"},
+		{"wrong session", "stolen", 2015, 1, "log in"},
+		{"no such day", "secret", 2015, 26, "404"},
+		{"not out yet", "secret", 9999, 1, "isn't out yet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, server := newFake(t, 0)
+			fetcher := &HTTPFetcher{BaseURL: server.URL, Session: tt.session, Interval: time.Millisecond}
+
+			_, err := fetcher.Fetch(tt.year, tt.day)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Fetch(%d, %d) = %v, want an error with %q", tt.year, tt.day, err, tt.want)
+			}
+			if got := fake.Requests(); got > 1 {
+				t.Errorf("the server got %d requests, want no retries", got)
+			}
+		})
+	}
+}
+
+func TestFetchBacksOffWhenRateLimited(t *testing.T) {
+	fake, server := newFake(t, 200*time.Millisecond)
+	fetcher := &HTTPFetcher{BaseURL: server.URL, Session: "secret", Interval: time.Millisecond}
+
+	if _, err := fetcher.Fetch(2015, 1); err != nil {
+		t.Fatal(err)
+	}
+	// asked again too soon, turned down, and retried after the Retry-After
+	start := time.Now()
+	data, err := fetcher.Fetch(2015, 2)
+	if err != nil {
+		t.Fatalf("Fetch after being rate limited: %v", err)
+	}
+	if string(data) != "xx\n" {
+		t.Errorf("Fetch(2015, 2) = %q, want %q", data, "xx\n")
+	}
+	if got := fake.Requests(); got != 3 {
+		t.Errorf("the server got %d requests, want 3 with one turned down", got)
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("retried after %s, want the Retry-After of a second", waited)
+	}
+}
+
+func TestReleased(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{time.Date(2024, time.November, 30, 23, 59, 0, 0, est), false},
+		{time.Date(2024, time.December, 1, 0, 0, 0, 0, est), true},
+		{time.Date(2024, time.December, 1, 4, 59, 0, 0, time.UTC), false},
+		{time.Date(2024, time.December, 1, 5, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		if got := released(2024, 1, tt.now); got != tt.want {
+			t.Errorf("released(2024, 1, %s) = %v, want %v", tt.now, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Package parity runs every language's solution of a day, the Go runner,
+// main.rs and index.js, and checks that they print the same answers.
+package parity
+
+import (
+	"advent-code/internal/golden"
+	"aoc"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// cargoToml is the manifest compare.sh builds main.rs files with.
+const cargoToml = `[package]
+name = "%s"
+version = "0.1.0"
+edition = "2021"
+
+[dependencies]
+tokio = { version = "1.28", features = ["full"] }
+regex = "1.7"
+itertools = "0.10.5"
+rayon = "1.8"
+`
+
+// ErrNoPorts is returned when none of the selected days has a Rust or JS solution.
+var ErrNoPorts = errors.New("none of these days has a Rust or JS solution")
+
+// Language is one way a day can be solved.
+type Language struct {
+	Name string
+	// File marks the day directories that have this language's solution.
+	File string
+	// command builds the solution if needed and returns how to run it.
+	command func(c *Checker, day *aoc.Day) (*exec.Cmd, error)
+}
+
+// Languages are checked in this order, Go being the reference when a part has
+// no golden answer yet.
+var Languages = []Language{
+	{Name: "go", File: "main.go", command: (*Checker).goCommand},
+	{Name: "rust", File: "main.rs", command: (*Checker).rustCommand},
+	{Name: "bun", File: "index.js", command: scriptCommand("bun")},
+	{Name: "node", File: "index.js", command: scriptCommand("node")},
+}
+
+// Result is what one language answered for a day.
+type Result struct {
+	Day      *aoc.Day
+	Language string
+	Answers  []string
+	Time     time.Duration
+	Err      error
+	// Mismatch explains how the answers differ from the expected ones.
+	Mismatch string
+}
+
+// Checker builds solutions under Work and runs them from the repository at Root.
+type Checker struct {
+	Root string
+	Work string
+	// Runs is how many times each solution runs, its time being the mean.
+	Runs    int
+	Answers golden.Answers
+
+	goBinary string
+	built    map[string]bool
+}
+
+// Ported reports whether a day has a solution in a language other than Go.
+func (c *Checker) Ported(day *aoc.Day) bool {
+	for _, language := range c.present(day) {
+		if language.Name != "go" {
+			return true
+		}
+	}
+	return false
+}
+
+// present lists the languages a day has a solution in that can run here.
+func (c *Checker) present(day *aoc.Day) []Language {
+	var languages []Language
+	for _, language := range Languages {
+		if _, err := os.Stat(filepath.Join(c.Root, day.Dir(), language.File)); err != nil {
+			continue
+		}
+		if language.Name == "bun" || language.Name == "node" {
+			if _, err := exec.LookPath(language.Name); err != nil {
+				continue
+			}
+		}
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// Day runs each language's solution of the day and compares their answers
+// against the golden ones, or against Go's for parts that have none.
+func (c *Checker) Day(day *aoc.Day) []Result {
+	var results []Result
+	for _, language := range c.present(day) {
+		result := Result{Day: day, Language: language.Name}
+		result.Answers, result.Time, result.Err = c.run(language, day)
+		results = append(results, result)
+	}
+
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Mismatch = c.compare(day, results[i].Answers, results)
+		}
+	}
+	return results
+}
+
+func (c *Checker) run(language Language, day *aoc.Day) ([]string, time.Duration, error) {
+	var answers []string
+	var total time.Duration
+	for i := 0; i < c.Runs; i++ {
+		cmd, err := language.command(c, day)
+		if err != nil {
+			return nil, 0, err
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		start := time.Now()
+		err = cmd.Run()
+		total += time.Since(start)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w%s", language.Name, err, indent(stderr.String()))
+		}
+		if i == 0 {
+			answers = Normalise(stdout.String())
+		}
+	}
+	return answers, total / time.Duration(c.Runs), nil
+}
+
+func (c *Checker) compare(day *aoc.Day, answers []string, results []Result) string {
+	var reference []string
+	if len(results) > 0 && results[0].Language == "go" {
+		reference = results[0].Answers
+	}
+
+	var problems []string
+	for part := 1; part <= 2; part++ {
+		want := ""
+		if known, ok := c.Answers[golden.Key{Year: day.Year, Day: day.Day, Part: part}]; ok {
+			want = known.String()
+		} else if part <= len(reference) {
+			want = reference[part-1]
+		}
+		if want == "" {
+			continue
+		}
+
+		switch {
+		case part > len(answers):
+			problems = append(problems, fmt.Sprintf("part %d: no answer, want %s", part, want))
+		case answers[part-1] != want:
+			problems = append(problems, fmt.Sprintf("part %d: got %s, want %s", part, answers[part-1], want))
+		}
+	}
+	return strings.Join(problems, "; ")
+}
+
+func (c *Checker) goCommand(day *aoc.Day) (*exec.Cmd, error) {
+	if c.goBinary == "" {
+		binary := filepath.Join(c.Work, "aoc")
+		if err := c.build(c.Root, nil, "go", "build", "-o", binary, "./cmd/aoc"); err != nil {
+			return nil, err
+		}
+		c.goBinary = binary
+	}
+
+	cmd := exec.Command(c.goBinary, "run", fmt.Sprint(day.Year), fmt.Sprint(day.Day))
+	cmd.Dir = c.Root
+	return cmd, nil
+}
+
+// rustCommand builds main.rs the way compare.sh does, in a throwaway crate
+// sharing one target directory so the dependencies only compile once.
+func (c *Checker) rustCommand(day *aoc.Day) (*exec.Cmd, error) {
+	name := fmt.Sprintf("advent_code_%d_%d", day.Year, day.Day)
+	crate := filepath.Join(c.Work, "rust", name)
+	target := filepath.Join(c.Work, "rust", "target")
+	binary := filepath.Join(target, "release", name)
+
+	if !c.built[name] {
+		source, err := os.ReadFile(filepath.Join(c.Root, day.Dir(), "main.rs"))
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Join(crate, "src"), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(crate, "src", "main.rs"), source, 0o644); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(crate, "Cargo.toml"), []byte(fmt.Sprintf(cargoToml, name)), 0o644); err != nil {
+			return nil, err
+		}
+		env := append(os.Environ(), "CARGO_TARGET_DIR="+target)
+		if err := c.build(crate, env, "cargo", "build", "--release", "--quiet"); err != nil {
+			return nil, err
+		}
+		if c.built == nil {
+			c.built = make(map[string]bool)
+		}
+		c.built[name] = true
+	}
+
+	// the Rust solutions read data.txt from the working directory
+	cmd := exec.Command(binary)
+	cmd.Dir = filepath.Join(c.Root, day.Dir())
+	return cmd, nil
+}
+
+// scriptCommand runs index.js with a JS runtime, from its day directory too.
+func scriptCommand(runtime string) func(c *Checker, day *aoc.Day) (*exec.Cmd, error) {
+	return func(c *Checker, day *aoc.Day) (*exec.Cmd, error) {
+		cmd := exec.Command(runtime, "index.js")
+		cmd.Dir = filepath.Join(c.Root, day.Dir())
+		return cmd, nil
+	}
+}
+
+func (c *Checker) build(dir string, env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s build: %w%s", name, err, indent(string(output)))
+	}
+	return nil
+}
+
+// answerLine matches what is left of a line that printed an answer: a number,
+// or numbers separated by commas.
+var answerLine = regexp.MustCompile(`^-?\d+(,-?\d+)*$`)
+
+// Normalise picks the answers out of whatever a solution printed, be it
+// "Part 1: 11", "distance: 11", "{ distance: 11 }", "11n" or a plain "11".
+// Lines that aren't answers, banners and visualisations, are dropped.
+func Normalise(output string) []string {
+	var answers []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.Trim(line, " \t\r{}")
+		if i := strings.LastIndex(line, ":"); i >= 0 {
+			line = strings.TrimSpace(line[i+1:])
+		}
+		line = strings.Trim(line, `'"`)
+		if strings.HasSuffix(line, "n") && answerLine.MatchString(line[:len(line)-1]) {
+			line = line[:len(line)-1]
+		}
+		if answerLine.MatchString(line) {
+			answers = append(answers, line)
+		}
+	}
+	return answers
+}
+
+// Failed counts the results that errored or disagree.
+func Failed(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil || r.Mismatch != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Print writes the answers and timings of every language as a table, with the
+// reason a language failed below it.
+func Print(w io.Writer, results []Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tlanguage\tpart 1\tpart 2\ttime\t")
+	var problems []string
+	for _, r := range results {
+		answers := append(append([]string(nil), r.Answers...), "", "")
+		status := "✅"
+		switch {
+		case r.Err != nil:
+			status = "❌"
+			problems = append(problems, fmt.Sprintf("%s %s: %v", r.Day, r.Language, r.Err))
+		case r.Mismatch != "":
+			status = "❌"
+			problems = append(problems, fmt.Sprintf("%s %s: %s", r.Day, r.Language, r.Mismatch))
+		}
+		fmt.Fprintf(tw, "%d/%d\t%s\t%s\t%s\t%s\t%s\n",
+			r.Day.Year, r.Day.Day, r.Language, answers[0], answers[1], r.Time.Round(time.Millisecond), status)
+	}
+	tw.Flush()
+
+	for _, problem := range problems {
+		fmt.Fprintln(w, problem)
+	}
+}
+
+func indent(output string) string {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return ""
+	}
+	return "\n\t" + strings.ReplaceAll(output, "\n", "\n\t")
+}
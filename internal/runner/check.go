@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"advent-code/internal/golden"
+	"aoc"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Check solves every part of the days and compares the answers with the
+// golden ones. With update, new or changed answers are recorded instead.
+func Check(days []*aoc.Day, update bool) error {
+	root, err := Root()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(root, golden.FileName)
+
+	answers, err := golden.Load(path)
+	if err != nil {
+		return err
+	}
+
+	failed, missing, changed := 0, 0, 0
+	for _, day := range days {
+		in, err := ReadInput(day)
+		if err != nil {
+			return fmt.Errorf("%s: %w", day, err)
+		}
+
+		// variants have to agree with the golden answers too, but only the
+		// main solution may record them
+		for _, solution := range append([]*aoc.Day{day}, aoc.Variants(day.Year, day.Day)...) {
+			for part := 1; part <= 2; part++ {
+				if !solution.HasPart(part) {
+					continue
+				}
+				key := golden.Key{Year: day.Year, Day: day.Day, Part: part}
+				label := fmt.Sprintf("%s part %d", solution, part)
+
+				start := time.Now()
+				got, err := Solve(solution, part, in)
+				elapsed := time.Since(start).Seconds()
+				if err != nil {
+					failed++
+					fmt.Printf("❌ %s: %v\n", label, err)
+					continue
+				}
+
+				want, known := answers[key]
+				switch {
+				case known && want.Equal(got):
+					fmt.Printf("✅ %s (%.3fs)\n", label, elapsed)
+				case update && solution.Variant == "":
+					changed++
+					answers[key] = got
+					fmt.Printf("📝 %s: recorded %s\n", label, got)
+				case !known:
+					missing++
+					fmt.Printf("❔ %s: no golden answer, got %s\n", label, got)
+				default:
+					failed++
+					fmt.Printf("❌ %s: got %s, want %s\n", label, got, want)
+				}
+			}
+		}
+	}
+
+	if update && changed > 0 {
+		if err := answers.Save(path); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("========================================")
+	if failed > 0 {
+		return fmt.Errorf("%d answers do not match %s", failed, golden.FileName)
+	}
+	if missing > 0 {
+		fmt.Printf("⚠️  %d parts have no golden answer, record them with -update\n", missing)
+		return nil
+	}
+	fmt.Println("✅ All answers match")
+	return nil
+}
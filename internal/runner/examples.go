@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"aoc"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExamplesDir holds a day's example inputs, next to its data.txt.
+const ExamplesDir = "examples"
+
+// Example is one of a puzzle's worked examples, stored as examples/<name>.txt.
+// The file starts with "key: value" lines up to a "---" separator: part1 and
+// part2 are the expected answers, any other key is a param for the day.
+//
+//	part1: 22
+//	part2: 6,1
+//	size: 7
+//	bytes: 12
+//	---
+//	5,4
+//	4,2
+//
+// Only the parts with an expected answer are solved, unless none has one.
+type Example struct {
+	Input aoc.Input
+	Want  [2]string
+}
+
+// ParseExample reads an example file's header and input, whatever its line
+// endings.
+func ParseExample(name, text string) (Example, error) {
+	text = aoc.Normalise(text)
+	header, body, found := strings.Cut(text, "\n---\n")
+	if !found {
+		if rest, ok := strings.CutPrefix(text, "---\n"); ok {
+			header, body, found = "", rest, true
+		}
+	}
+	if !found {
+		return Example{}, fmt.Errorf("%s: missing the --- line between header and input", name)
+	}
+
+	example := Example{Input: aoc.NewInput(name, []byte(body))}
+	example.Input.Params = map[string]string{}
+	for i, line := range strings.Split(header, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return Example{}, fmt.Errorf("%s:%d: expected key: value, got %q", name, i+1, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case "part1":
+			example.Want[0] = value
+		case "part2":
+			example.Want[1] = value
+		default:
+			example.Input.Params[key] = value
+		}
+	}
+	return example, nil
+}
+
+// LoadExamples reads every example of the day, sorted by name. Days without an
+// examples directory simply have none.
+func LoadExamples(day *aoc.Day) ([]Example, error) {
+	root, err := Root()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(day.Dir(), ExamplesDir)
+	entries, err := os.ReadDir(filepath.Join(root, dir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var examples []Example
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".txt" {
+			continue
+		}
+		name := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			return nil, err
+		}
+		example, err := ParseExample(name, string(data))
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, example)
+	}
+	return examples, nil
+}
+
+// RunExamples solves the day's examples and fails on the first wrong answer.
+func RunExamples(day *aoc.Day) error {
+	examples, err := LoadExamples(day)
+	if err != nil {
+		return err
+	}
+
+	for _, example := range examples {
+		name := strings.TrimSuffix(filepath.Base(example.Input.Name), ".txt")
+		checkAll := example.Want == [2]string{}
+
+		for part := 1; part <= 2; part++ {
+			want := example.Want[part-1]
+			if !day.HasPart(part) || (want == "" && !checkAll) {
+				continue
+			}
+
+			got, err := Solve(day, part, example.Input)
+			if err != nil {
+				return fmt.Errorf("example %s part %d: %w", name, part, err)
+			}
+
+			switch {
+			case want == "":
+				fmt.Printf("Example %s part %d: %s\n", name, part, got)
+			case got.Equal(aoc.String(want)):
+				fmt.Printf("Example %s part %d: %s ✅\n", name, part, got)
+			default:
+				fmt.Printf("Example %s part %d: %s ❌ want %s\n", name, part, got, want)
+				return fmt.Errorf("example %s part %d: got %s, want %s", name, part, got, want)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestParseExample(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		want   [2]string
+		params map[string]string
+		input  string
+		err    string
+	}{
+		{
+			name:   "answers and params",
+			text:   "part1: 22\npart2: 6,1\nsize: 7\nbytes: 12\n---\n5,4\n4,2\n",
+			want:   [2]string{"22", "6,1"},
+			params: map[string]string{"size": "7", "bytes": "12"},
+			input:  "5,4\n4,2",
+		},
+		{
+			name:   "one part",
+			text:   "part2: 4\n---\n1 2\n",
+			want:   [2]string{"", "4"},
+			params: map[string]string{},
+			input:  "1 2",
+		},
+		{
+			name:   "no header",
+			text:   "---\n1 2\n3 4",
+			params: map[string]string{},
+			input:  "1 2\n3 4",
+		},
+		{
+			name:   "spaces and blank header lines",
+			text:   "  part1 :  7  \n\nwidth:11\n---\nabc\n",
+			want:   [2]string{"7", ""},
+			params: map[string]string{"width": "11"},
+			input:  "abc",
+		},
+		{
+			name:   "crlf",
+			text:   "part1: 2\r\nsize: 3\r\n---\r\n7 6 4\r\n1 2 7\r\n",
+			want:   [2]string{"2", ""},
+			params: map[string]string{"size": "3"},
+			input:  "7 6 4\n1 2 7",
+		},
+		{
+			name:   "byte order mark",
+			text:   "\ufeffpart1: 1\n---\nx\n",
+			want:   [2]string{"1", ""},
+			input:  "x",
+			params: map[string]string{},
+		},
+		{
+			name: "missing separator",
+			text: "part1: 2\n7 6 4\n",
+			err:  "example.txt: missing the --- line",
+		},
+		{
+			name: "separator needs its own line",
+			text: "part1: 2\n----\n7 6 4\n",
+			err:  "missing the --- line",
+		},
+		{
+			name: "header without a colon",
+			text: "part1: 2\nsize 7\n---\n7 6 4\n",
+			err:  `example.txt:2: expected key: value, got "size 7"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			example, err := ParseExample("example.txt", tt.text)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error %v, want one with %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if example.Want != tt.want {
+				t.Errorf("want %q, expected %q", example.Want, tt.want)
+			}
+			if !maps.Equal(example.Input.Params, tt.params) {
+				t.Errorf("params %v, want %v", example.Input.Params, tt.params)
+			}
+			if example.Input.Text != tt.input {
+				t.Errorf("input %q, want %q", example.Input.Text, tt.input)
+			}
+			if example.Input.Name != "example.txt" {
+				t.Errorf("input named %q", example.Input.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Package runner finds registered days and solves them against their inputs.
+package runner
+
+import (
+	"advent-code/internal/inputs"
+	"aoc"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const rootModule = "advent-code"
+
+// Options tune how Run solves the days.
+type Options struct {
+	// Examples makes each day's examples pass before its real input runs.
+	Examples bool
+	// Params override the days' defaults, e.g. connectionCount for 2025 day 8.
+	Params map[string]string
+	// Inputs replace data.txt, for running a single day against other inputs.
+	Inputs []aoc.Input
+}
+
+// Run solves every day in turn, printing the same banners runAll.sh used to.
+func Run(days []*aoc.Day, opts Options) error {
+	if len(opts.Inputs) > 0 && len(days) != 1 {
+		return fmt.Errorf("inputs can only be given to a single day, not %d", len(days))
+	}
+
+	totalStart := time.Now()
+	for _, day := range days {
+		fmt.Printf("========== Running %s ==========\n", day)
+		start := time.Now()
+		if opts.Examples {
+			if err := RunExamples(day); err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+		}
+		if len(opts.Inputs) > 0 {
+			if err := runInputs(day, opts); err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+		} else {
+			in, err := ReadInput(day)
+			if err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+			if err := RunDay(day, in, opts.Params); err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+		}
+		fmt.Printf("⭐️⭐️  %s completed in %.3f seconds\n\n", day, time.Since(start).Seconds())
+	}
+
+	fmt.Println("========================================")
+	fmt.Printf("✅ Total time: %.3f seconds\n", time.Since(totalStart).Seconds())
+	return nil
+}
+
+// runInputs solves the day against each of the given inputs, carrying on past
+// the ones it fails on.
+func runInputs(day *aoc.Day, opts Options) error {
+	failed := 0
+	for _, in := range opts.Inputs {
+		fmt.Printf("---------- %s ----------\n", in.Name)
+		if err := RunDay(day, in, opts.Params); err != nil {
+			failed++
+			fmt.Printf("❌ %v\n", err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed on %d of %d inputs", failed, len(opts.Inputs))
+	}
+	return nil
+}
+
+// RunDay solves each part of a day against the input and prints the answers.
+func RunDay(day *aoc.Day, in aoc.Input, params map[string]string) error {
+	in.Params = params
+
+	for part := 1; part <= 2; part++ {
+		if !day.HasPart(part) {
+			continue
+		}
+		answer, err := Solve(day, part, in)
+		if err != nil {
+			return fmt.Errorf("part %d: %w", part, err)
+		}
+		fmt.Printf("Part %d: %s\n", part, answer)
+	}
+	return nil
+}
+
+// Solve parses the input afresh and solves one part of the day.
+func Solve(day *aoc.Day, part int, in aoc.Input) (aoc.Answer, error) {
+	parsed, err := day.Parse(in)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return day.Solve(part, parsed)
+}
+
+// ReadInput loads the day's input from the input cache, or its data.txt for
+// the days that still have one committed, or else fetches it into the cache.
+func ReadInput(day *aoc.Day) (aoc.Input, error) {
+	store, err := inputs.Default()
+	if err != nil {
+		return aoc.Input{}, err
+	}
+	if data, ok, err := store.Cached(day.Year, day.Day); err != nil || ok {
+		return aoc.NewInput(store.Path(day.Year, day.Day), data), err
+	}
+
+	root, err := Root()
+	if err != nil {
+		return aoc.Input{}, err
+	}
+	name := filepath.Join(day.Dir(), "data.txt")
+	data, err := os.ReadFile(filepath.Join(root, name))
+	if err == nil {
+		return aoc.NewInput(name, data), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return aoc.Input{}, err
+	}
+
+	data, err = store.Get(day.Year, day.Day)
+	if err != nil {
+		return aoc.Input{}, err
+	}
+	return aoc.NewInput(store.Path(day.Year, day.Day), data), nil
+}
+
+// ReadInputs loads the inputs at path: "-" for stdin, a file, or a directory
+// whose files, sorted by name, are each an input. Hidden files are skipped.
+func ReadInputs(path string) ([]aoc.Input, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return []aoc.Input{aoc.NewInput("stdin", data)}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []aoc.Input{aoc.NewInput(path, data)}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var inputs []aoc.Input
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		name := filepath.Join(path, entry.Name())
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, aoc.NewInput(name, data))
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no inputs in %s", path)
+	}
+	return inputs, nil
+}
+
+// Select resolves "<year|all> [day|all]" or a day directory into days.
+func Select(args []string) ([]*aoc.Day, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, errors.New("expected <year|all> [day|all]")
+	}
+
+	years := aoc.Years()
+	if args[0] != "all" {
+		year, err := strconv.Atoi(args[0])
+		if err != nil {
+			if info, statErr := os.Stat(args[0]); statErr == nil && info.IsDir() && len(args) == 1 {
+				return dayInDirectory(args[0])
+			}
+			return nil, fmt.Errorf("invalid year %q", args[0])
+		}
+		years = []int{year}
+	}
+
+	dayArg := "all"
+	if len(args) == 2 {
+		dayArg = args[1]
+	}
+
+	var days []*aoc.Day
+	for _, year := range years {
+		if dayArg == "all" {
+			days = append(days, aoc.Days(year)...)
+			continue
+		}
+		n, err := strconv.Atoi(dayArg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", dayArg)
+		}
+		if day, ok := aoc.Lookup(year, n); ok {
+			days = append(days, day)
+		}
+	}
+
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no registered day matches %s", strings.Join(args, " "))
+	}
+	return days, nil
+}
+
+func dayInDirectory(dir string) ([]*aoc.Day, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	abs = filepath.ToSlash(abs)
+
+	for _, year := range aoc.Years() {
+		for _, day := range aoc.Days(year) {
+			if strings.HasSuffix(abs, "/"+day.Dir()) {
+				return []*aoc.Day{day}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no registered day lives in %s", dir)
+}
+
+// Root walks up from the working directory to the repository root, so the
+// runner works from anywhere inside the repository.
+func Root() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil && strings.HasPrefix(string(data), "module "+rootModule+"\n") {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not inside the advent-code repository")
+		}
+		dir = parent
+	}
+}
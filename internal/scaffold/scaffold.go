@@ -0,0 +1,228 @@
+// Package scaffold creates the directory of a new day from the README's Go
+// skeleton, wired into the runner and laid out like the rest of its year.
+package scaffold
+
+import (
+	"aoc"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DaysFile is the runner's list of day imports, relative to the repository root.
+const DaysFile = "cmd/aoc/days.go"
+
+// exampleFixture has no expectations yet, so the runner solves it without
+// checking anything until the answers are filled in.
+const exampleFixture = `part1:
+part2:
+---
+`
+
+// testFile checks the parts against the puzzle's example once it's pasted in.
+const testFile = `package day%[1]d
+
+import (
+	"aoc"
+	"testing"
+)
+
+// example is the puzzle's example input, with its answers in want.
+const example = ` + "``" + `
+
+func TestParts(t *testing.T) {
+	want := [2]string{"", ""}
+	parts := []aoc.Part[[]string]{part1, part2}
+
+	for i, part := range parts {
+		if example == "" || want[i] == "" {
+			t.Skipf("no example answer for part %%d yet", i+1)
+		}
+		data, err := formatData(aoc.Input{Name: "example", Text: example})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := part(data)
+		if err != nil {
+			t.Fatalf("part %%d: %%v", i+1, err)
+		}
+		if got.String() != want[i] {
+			t.Errorf("part %%d: got %%s, want %%s", i+1, got, want[i])
+		}
+	}
+}
+`
+
+// Layout is where a day goes and which module it belongs to.
+type Layout struct {
+	// Dir of the day, relative to the repository root.
+	Dir string
+	// Package is the day's import path.
+	Package string
+	// Module is the year's module when it needs creating, for a new year.
+	Module string
+	// ModuleDir is where that module's go.mod goes.
+	ModuleDir string
+}
+
+// Plan works out the layout of a day. Every year is a single aoc<year> module
+// with a package per day, in the directory the runner expects the day in.
+func Plan(root string, year, day int) (Layout, error) {
+	yearDir := fmt.Sprint(year)
+	dir := filepath.FromSlash((&aoc.Day{Year: year, Day: day}).Dir())
+	module := fmt.Sprintf("aoc%d", year)
+	layout := Layout{Dir: dir, Package: module + "/" + filepath.Base(dir)}
+
+	if _, err := os.Stat(filepath.Join(root, yearDir, "go.mod")); errors.Is(err, fs.ErrNotExist) {
+		layout.Module = module
+		layout.ModuleDir = yearDir
+	} else if err != nil {
+		return Layout{}, err
+	}
+	return layout, nil
+}
+
+// Skeleton is the first Go code block under the README's "### Go" heading.
+func Skeleton(readme string) (string, error) {
+	_, section, ok := strings.Cut(readme, "\n### Go\n")
+	if !ok {
+		return "", errors.New("the README has no ### Go section")
+	}
+	_, code, ok := strings.Cut(section, "```go\n")
+	if !ok {
+		return "", errors.New("the README's ### Go section has no go code block")
+	}
+	code, _, ok = strings.Cut(code, "```")
+	if !ok {
+		return "", errors.New("the README's go code block isn't closed")
+	}
+	return code, nil
+}
+
+var placeholders = regexp.MustCompile(`\b(dayN|YEAR|N)\b`)
+
+// New creates the day in the repository at root and returns the files it
+// wrote. An existing day directory is never touched.
+func New(root string, year, day int) ([]string, error) {
+	if day < 1 || day > 25 {
+		return nil, fmt.Errorf("there is no day %d in an advent calendar", day)
+	}
+
+	layout, err := Plan(root, year, day)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(root, layout.Dir)); !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s already exists, not overwriting it", layout.Dir)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(root, "README.md"))
+	if err != nil {
+		return nil, err
+	}
+	skeleton, err := Skeleton(string(readme))
+	if err != nil {
+		return nil, err
+	}
+	source := placeholders.ReplaceAllStringFunc(skeleton, func(placeholder string) string {
+		switch placeholder {
+		case "dayN":
+			return fmt.Sprintf("day%d", day)
+		case "YEAR":
+			return fmt.Sprint(year)
+		}
+		return fmt.Sprint(day)
+	})
+
+	files := map[string]string{
+		filepath.Join(layout.Dir, "main.go"):                 source,
+		filepath.Join(layout.Dir, "main_test.go"):            fmt.Sprintf(testFile, day),
+		filepath.Join(layout.Dir, "examples", "example.txt"): exampleFixture,
+	}
+	moduleCreated := false
+	if layout.Module != "" {
+		goMod := filepath.Join(layout.ModuleDir, "go.mod")
+		if _, err := os.Stat(filepath.Join(root, goMod)); errors.Is(err, fs.ErrNotExist) {
+			depth := strings.Count(filepath.ToSlash(layout.ModuleDir), "/") + 1
+			files[goMod] = fmt.Sprintf("module %s\n\ngo %s\n\nrequire aoc v0.0.0\n\nreplace aoc => %saoc\n",
+				layout.Module, goVersion(root), strings.Repeat("../", depth))
+			moduleCreated = true
+		}
+	}
+
+	var written []string
+	for name := range files {
+		written = append(written, name)
+	}
+	sort.Strings(written)
+	for _, name := range written {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+			return written, err
+		}
+	}
+
+	if moduleCreated {
+		// the runner's module needs the new one, found in the tree
+		edit := exec.Command("go", "mod", "edit",
+			"-require="+layout.Module+"@v0.0.0",
+			"-replace="+layout.Module+"=./"+filepath.ToSlash(layout.ModuleDir))
+		edit.Dir = root
+		if output, err := edit.CombinedOutput(); err != nil {
+			return written, fmt.Errorf("go mod edit: %w: %s", err, output)
+		}
+		written = append(written, "go.mod")
+	}
+
+	if err := register(root, layout.Package); err != nil {
+		return written, err
+	}
+	return append(written, DaysFile), nil
+}
+
+// register adds the day's blank import to the runner, keeping the imports in
+// the order gofmt would.
+func register(root, pkg string) error {
+	path := filepath.Join(root, DaysFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	head, rest, ok := strings.Cut(string(data), "import (\n")
+	if !ok {
+		return fmt.Errorf("%s has no import block", DaysFile)
+	}
+	block, tail, ok := strings.Cut(rest, ")\n")
+	if !ok {
+		return fmt.Errorf("%s has an unclosed import block", DaysFile)
+	}
+
+	imports := strings.Split(strings.TrimSuffix(block, "\n"), "\n")
+	imports = append(imports, fmt.Sprintf("\t_ %q", pkg))
+	sort.Strings(imports)
+
+	return os.WriteFile(path, []byte(head+"import (\n"+strings.Join(imports, "\n")+"\n)\n"+tail), 0o644)
+}
+
+// goVersion is the go directive of the root module, shared by every module.
+func goVersion(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if version, ok := strings.CutPrefix(line, "go "); ok {
+				return strings.TrimSpace(version)
+			}
+		}
+	}
+	return "1.23.3"
+}
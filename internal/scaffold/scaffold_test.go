@@ -0,0 +1,169 @@
+package scaffold
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const days = `package main
+
+// every solved day registers itself with the aoc package on import
+import (
+	_ "aoc2024/1"
+	_ "aoc2025/day1"
+)
+`
+
+// newRepo is a repository with the real README and aoc library, and a runner
+// importing a day of 2024 and 2025.
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("scaffolding needs the go command")
+	}
+	root := t.TempDir()
+	readme, err := os.ReadFile("../../README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	library, err := filepath.Abs("../../aoc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(t, filepath.Join(root, "README.md"), string(readme))
+	write(t, filepath.Join(root, "go.mod"), "module advent-code\n\ngo 1.23.3\n")
+	write(t, filepath.Join(root, DaysFile), days)
+	if err := os.Symlink(library, filepath.Join(root, "aoc")); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func write(t *testing.T, path, text string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestNew(t *testing.T) {
+	root := newRepo(t)
+
+	written, err := New(root, 2030, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.FromSlash("2030/day1/examples/example.txt"),
+		filepath.FromSlash("2030/day1/main.go"),
+		filepath.FromSlash("2030/day1/main_test.go"),
+		filepath.FromSlash("2030/go.mod"),
+		"go.mod",
+		DaysFile,
+	}
+	if !slices.Equal(written, want) {
+		t.Fatalf("wrote %q, want %q", written, want)
+	}
+
+	main := read(t, filepath.Join(root, "2030", "day1", "main.go"))
+	if !strings.HasPrefix(main, "package day1\n") || !strings.Contains(main, "aoc.Register(2030, 1,") {
+		t.Errorf("main.go has the placeholders left in:\n%s", main)
+	}
+	if got := read(t, filepath.Join(root, "2030", "day1", "examples", "example.txt")); got != exampleFixture {
+		t.Errorf("example.txt = %q", got)
+	}
+	wantMod := "module aoc2030\n\ngo 1.23.3\n\nrequire aoc v0.0.0\n\nreplace aoc => ../aoc\n"
+	if got := read(t, filepath.Join(root, "2030", "go.mod")); got != wantMod {
+		t.Errorf("2030/go.mod = %q, want %q", got, wantMod)
+	}
+	rootMod := read(t, filepath.Join(root, "go.mod"))
+	if !strings.Contains(rootMod, "aoc2030 v0.0.0") || !strings.Contains(rootMod, "aoc2030 => ./2030") {
+		t.Errorf("go.mod doesn't require the new year:\n%s", rootMod)
+	}
+
+	// a second day of the year joins its module
+	written, err = New(root, 2030, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(written, "go.mod") || slices.Contains(written, filepath.FromSlash("2030/go.mod")) {
+		t.Errorf("the second day wrote a go.mod: %q", written)
+	}
+	wantDays := strings.Replace(days, "\t_ \"aoc2025/day1\"\n",
+		"\t_ \"aoc2025/day1\"\n\t_ \"aoc2030/day1\"\n\t_ \"aoc2030/day12\"\n", 1)
+	if got := read(t, filepath.Join(root, DaysFile)); got != wantDays {
+		t.Errorf("%s =\n%s\nwant\n%s", DaysFile, got, wantDays)
+	}
+
+	// the days and their tests compile against the real library
+	vet := exec.Command("go", "vet", "./...")
+	vet.Dir = filepath.Join(root, "2030")
+	vet.Env = append(os.Environ(), "GOWORK=off", "GOPROXY=off", "GOFLAGS=")
+	if output, err := vet.CombinedOutput(); err != nil {
+		t.Errorf("go vet: %v\n%s", err, output)
+	}
+}
+
+func TestNewRefusesToOverwrite(t *testing.T) {
+	root := newRepo(t)
+	if _, err := New(root, 2024, 3); err != nil {
+		t.Fatal(err)
+	}
+	main := filepath.Join(root, "2024", "3", "main.go")
+	write(t, main, "package day3 // solved\n")
+	registered := read(t, filepath.Join(root, DaysFile))
+
+	written, err := New(root, 2024, 3)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("second New = %q, %v, want it to refuse", written, err)
+	}
+	if len(written) != 0 {
+		t.Errorf("second New wrote %q", written)
+	}
+	if got := read(t, main); got != "package day3 // solved\n" {
+		t.Errorf("main.go overwritten with\n%s", got)
+	}
+	if got := read(t, filepath.Join(root, DaysFile)); got != registered {
+		t.Errorf("%s changed by the second New:\n%s", DaysFile, got)
+	}
+}
+
+func TestNewRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		readme string
+		day    int
+		err    string
+	}{
+		{"day 0", "", 0, "there is no day 0"},
+		{"day 26", "", 26, "there is no day 26"},
+		{"no go section", "# Advent\n", 1, "the README has no ### Go section"},
+		{"no code block", "# Advent\n\n### Go\n\nnothing\n", 1, "has no go code block"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRepo(t)
+			write(t, filepath.Join(root, "README.md"), tt.readme)
+			_, err := New(root, 2030, tt.day)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %v, want one containing %q", err, tt.err)
+			}
+		})
+	}
+}
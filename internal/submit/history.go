@@ -0,0 +1,142 @@
+package submit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryFile keeps the attempts next to the cached inputs, out of the
+// repository.
+const HistoryFile = "submissions.jsonl"
+
+// Attempt is one answer sent, and what came of it.
+type Attempt struct {
+	Time    time.Time     `json:"time"`
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Outcome Outcome       `json:"outcome"`
+	Hint    string        `json:"hint,omitempty"`
+	Wait    time.Duration `json:"wait,omitempty"`
+}
+
+// History is every attempt made, oldest first, backed by a file a line each.
+type History struct {
+	path     string
+	Attempts []Attempt
+}
+
+// LoadHistory reads the attempts in path. A missing file is no attempts yet.
+func LoadHistory(path string) (*History, error) {
+	history := &History{path: path}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var attempt Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &attempt); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+		history.Attempts = append(history.Attempts, attempt)
+	}
+	return history, scanner.Err()
+}
+
+// Record adds an attempt to the history and its file.
+func (h *History) Record(attempt Attempt) error {
+	line, err := json.Marshal(attempt)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	h.Attempts = append(h.Attempts, attempt)
+	return nil
+}
+
+// Of lists the attempts at a part.
+func (h *History) Of(year, day, part int) []Attempt {
+	var attempts []Attempt
+	for _, a := range h.Attempts {
+		if a.Year == year && a.Day == day && a.Part == part {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts
+}
+
+// Check tells why an answer shouldn't be sent: the part is solved already,
+// the very answer was wrong before, earlier hints rule it out, or the site
+// asked to wait and the time isn't up.
+func (h *History) Check(year, day, part int, answer string, now time.Time) error {
+	value, numeric := parseNumber(answer)
+	for _, a := range h.Of(year, day, part) {
+		switch {
+		case a.Outcome == Correct:
+			return fmt.Errorf("already solved with %s", a.Answer)
+		case a.Outcome == Wrong && a.Answer == answer:
+			return fmt.Errorf("already tried on %s, %s", a.Time.Local().Format(time.DateTime), describe(a))
+		case a.Outcome != Wrong || !numeric:
+			continue
+		}
+
+		tried, ok := parseNumber(a.Answer)
+		switch {
+		case ok && a.Hint == TooHigh && value >= tried:
+			return fmt.Errorf("%s can't be right, %s", answer, describe(a))
+		case ok && a.Hint == TooLow && value <= tried:
+			return fmt.Errorf("%s can't be right, %s", answer, describe(a))
+		}
+	}
+
+	for _, a := range h.Attempts {
+		if a.Year != year || a.Day != day || a.Wait == 0 {
+			continue
+		}
+		if left := a.Time.Add(a.Wait).Sub(now); left > 0 {
+			return fmt.Errorf("the site asked to wait, %s left", left.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// describe sums an attempt up, like "41 was too high".
+func describe(a Attempt) string {
+	if a.Hint != "" {
+		return fmt.Sprintf("%s was %s", a.Answer, a.Hint)
+	}
+	return fmt.Sprintf("%s was %s", a.Answer, a.Outcome)
+}
+
+func parseNumber(answer string) (int64, bool) {
+	n, err := strconv.ParseInt(answer, 10, 64)
+	return n, err == nil
+}
@@ -0,0 +1,107 @@
+package submit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MockServer answers /<year>/day/<day>/answer posts the way the puzzle site
+// does, so submitting can be tried out without spending real attempts. Once a
+// part is solved it stays solved for as long as the server runs.
+type MockServer struct {
+	// Session is the only cookie value accepted.
+	Session string
+	// Answer returns the right answer of a part, false for parts it doesn't know.
+	Answer func(year, day, part int) (string, bool)
+	// Cooldown after a wrong answer, a minute when zero.
+	Cooldown time.Duration
+
+	mu     sync.Mutex
+	solved map[[3]int]bool
+	locked map[[2]int]time.Time
+}
+
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var year, day int
+	if _, err := fmt.Sscanf(r.URL.Path, "/%d/day/%d/answer", &year, &day); err != nil || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+	if cookie, err := r.Cookie("session"); err != nil || cookie.Value != s.Session {
+		http.Error(w, "Please log in.", http.StatusBadRequest)
+		return
+	}
+	part, err := strconv.Atoi(r.FormValue("level"))
+	answer := r.FormValue("answer")
+	if err != nil || answer == "" {
+		http.Error(w, "Missing level or answer.", http.StatusBadRequest)
+		return
+	}
+	want, ok := s.Answer(year, day, part)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.solved == nil {
+		s.solved = make(map[[3]int]bool)
+		s.locked = make(map[[2]int]time.Time)
+	}
+
+	back := fmt.Sprintf(` [<a href="/%d/day/%d">Return to Day %d</a>]`, year, day, day)
+	if s.solved[[3]int{year, day, part}] {
+		reply(w, "You don't seem to be solving the right level.  Did you already complete it?"+back)
+		return
+	}
+	if left := time.Until(s.locked[[2]int{year, day}]); left > 0 {
+		seconds := int(left.Round(time.Second).Seconds())
+		wait := fmt.Sprintf("%ds", seconds%60)
+		if seconds >= 60 {
+			wait = fmt.Sprintf("%dm %s", seconds/60, wait)
+		}
+		reply(w, "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have "+wait+" left to wait."+back)
+		return
+	}
+
+	if answer == want {
+		s.solved[[3]int{year, day, part}] = true
+		reply(w, "That's the right answer!  You are one gold star closer to saving Christmas."+back)
+		return
+	}
+
+	cooldown := s.Cooldown
+	if cooldown == 0 {
+		cooldown = time.Minute
+	}
+	s.locked[[2]int{year, day}] = time.Now().Add(cooldown)
+
+	hint := ""
+	got, gotErr := strconv.ParseInt(answer, 10, 64)
+	wanted, wantErr := strconv.ParseInt(want, 10, 64)
+	switch {
+	case gotErr != nil || wantErr != nil:
+	case got > wanted:
+		hint = "  your answer is too high."
+	case got < wanted:
+		hint = "  your answer is too low."
+	}
+	wait := "one minute"
+	switch {
+	case cooldown < time.Minute:
+		wait = fmt.Sprintf("%d seconds", int(cooldown.Seconds()))
+	case cooldown >= 2*time.Minute:
+		wait = fmt.Sprintf("%d minutes", int(cooldown.Minutes()))
+	}
+	reply(w, "That's not the right answer;"+hint+"  If you're stuck, make sure you're using the full input data.  Please wait "+wait+" before trying again."+back)
+}
+
+// reply wraps a message in the bits of page the site's replies have.
+func reply(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en-us\">\n<body>\n<main>\n<article><p>%s</p></article>\n</main>\n</body>\n</html>\n", message)
+}
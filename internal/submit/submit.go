@@ -0,0 +1,159 @@
+// Package submit posts answers to the puzzle site, makes sense of its replies
+// and keeps every attempt, so a wrong answer is never sent twice.
+package submit
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is what the site made of an answer.
+type Outcome string
+
+const (
+	Correct Outcome = "correct"
+	Wrong   Outcome = "wrong"
+	// Wait means the answer was not looked at, an earlier one was too recent.
+	Wait Outcome = "wait"
+	// Solved means the part had already been solved.
+	Solved  Outcome = "solved"
+	Unknown Outcome = "unknown"
+)
+
+// Hints the site gives about wrong numeric answers.
+const (
+	TooHigh = "too high"
+	TooLow  = "too low"
+)
+
+// Verdict is the site's reply to an answer.
+type Verdict struct {
+	Outcome Outcome
+	// Hint is TooHigh or TooLow for some wrong answers.
+	Hint string
+	// Wait is how long until another answer will be looked at.
+	Wait time.Duration
+	// Message is the reply's text, without the markup.
+	Message string
+}
+
+func (v Verdict) String() string {
+	switch {
+	case v.Outcome == Wrong && v.Hint != "":
+		return fmt.Sprintf("wrong, %s", v.Hint)
+	case v.Outcome == Wait:
+		return fmt.Sprintf("too soon, wait %s", v.Wait)
+	}
+	return string(v.Outcome)
+}
+
+// Client posts answers to <BaseURL>/<year>/day/<day>/answer with the session
+// cookie.
+type Client struct {
+	BaseURL string
+	Session string
+	// HTTP defaults to a client with a 30 second timeout.
+	HTTP *http.Client
+}
+
+// Submit posts the answer of a part and reads the verdict off the reply.
+func (c *Client) Submit(year, day, part int, answer string) (Verdict, error) {
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(c.BaseURL, "/"), year, day)
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "advent-code answer submitter (Go net/http)")
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	client := c.HTTP
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Verdict{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		message, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+		return Verdict{}, fmt.Errorf("POST %s: %s: %s", endpoint, resp.Status, message)
+	}
+	return Parse(string(body)), nil
+}
+
+var (
+	article = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tag     = regexp.MustCompile(`<[^>]*>`)
+	spaces  = regexp.MustCompile(`\s+`)
+	// "You have 34s left to wait." or "You have 2m 5s left to wait."
+	waitLeft = regexp.MustCompile(`You have (?:(\d+)m)?\s*(?:(\d+)s)? left to wait`)
+	// "please wait one minute before trying again" or "wait 5 minutes"
+	waitFixed = regexp.MustCompile(`wait (one|\d+) (minute|second)s?`)
+)
+
+// Parse reads the verdict off a reply to an answer. Replies are HTML pages
+// whose <article> says what happened, in plain English.
+func Parse(page string) Verdict {
+	text := page
+	if match := article.FindStringSubmatch(page); match != nil {
+		text = match[1]
+	}
+	text = strings.TrimSpace(spaces.ReplaceAllString(html.UnescapeString(tag.ReplaceAllString(text, "")), " "))
+	verdict := Verdict{Outcome: Unknown, Message: text}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		verdict.Outcome = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		verdict.Outcome = Wrong
+		switch {
+		case strings.Contains(text, "your answer is too high"):
+			verdict.Hint = TooHigh
+		case strings.Contains(text, "your answer is too low"):
+			verdict.Hint = TooLow
+		}
+		verdict.Wait = waitFor(text)
+	case strings.Contains(text, "You gave an answer too recently"):
+		verdict.Outcome = Wait
+		verdict.Wait = waitFor(text)
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		verdict.Outcome = Solved
+	}
+	return verdict
+}
+
+// waitFor finds how long a reply says to wait before answering again.
+func waitFor(text string) time.Duration {
+	if match := waitLeft.FindStringSubmatch(text); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+	if match := waitFixed.FindStringSubmatch(text); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			n = 1
+		}
+		if match[2] == "second" {
+			return time.Duration(n) * time.Second
+		}
+		return time.Duration(n) * time.Minute
+	}
+	return 0
+}
@@ -0,0 +1,197 @@
+package submit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMock serves a mock whose right answer to every part is 42, counting the
+// requests that reach it.
+func newMock(t *testing.T) (*Client, *atomic.Int64) {
+	t.Helper()
+	mock := &MockServer{
+		Session:  "secret",
+		Answer:   func(year, day, part int) (string, bool) { return "42", day <= 25 },
+		Cooldown: 30 * time.Second,
+	}
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		mock.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return &Client{BaseURL: server.URL, Session: "secret"}, &requests
+}
+
+func TestSubmitAgainstMock(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		// want is the verdict of the last answer
+		want Verdict
+	}{
+		{"right", []string{"42"}, Verdict{Outcome: Correct}},
+		{"right twice", []string{"42", "42"}, Verdict{Outcome: Solved}},
+		{"wrong", []string{"forty-two"}, Verdict{Outcome: Wrong, Wait: 30 * time.Second}},
+		{"too high", []string{"43"}, Verdict{Outcome: Wrong, Hint: TooHigh, Wait: 30 * time.Second}},
+		{"too low", []string{"41"}, Verdict{Outcome: Wrong, Hint: TooLow, Wait: 30 * time.Second}},
+		{"rate limited", []string{"41", "42"}, Verdict{Outcome: Wait, Wait: 30 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newMock(t)
+			var got Verdict
+			for _, answer := range tt.answers {
+				var err error
+				if got, err = client.Submit(2015, 1, 1, answer); err != nil {
+					t.Fatalf("Submit(%s): %v", answer, err)
+				}
+			}
+			if got.Outcome != tt.want.Outcome || got.Hint != tt.want.Hint {
+				t.Errorf("verdict %s, want %s (%s)", got, tt.want, got.Message)
+			}
+			// the rate limited reply counts down from the cooldown
+			if got.Wait > tt.want.Wait || tt.want.Wait-got.Wait > time.Second {
+				t.Errorf("wait %s, want %s", got.Wait, tt.want.Wait)
+			}
+		})
+	}
+}
+
+func TestSubmitErrors(t *testing.T) {
+	client, _ := newMock(t)
+	client.Session = "stolen"
+	if _, err := client.Submit(2015, 1, 1, "42"); err == nil || !strings.Contains(err.Error(), "log in") {
+		t.Errorf("Submit with the wrong session: %v, want an error asking to log in", err)
+	}
+
+	client.Session = "secret"
+	if _, err := client.Submit(2015, 26, 1, "42"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Submit for a day that doesn't exist: %v, want a 404", err)
+	}
+}
+
+// attempt submits an answer the way the submit command does: checked against
+// the history first, and recorded after.
+func attempt(h *History, c *Client, answer string, now time.Time) (Verdict, error) {
+	if err := h.Check(2015, 1, 1, answer, now); err != nil {
+		return Verdict{}, err
+	}
+	verdict, err := c.Submit(2015, 1, 1, answer)
+	if err != nil {
+		return Verdict{}, err
+	}
+	return verdict, h.Record(Attempt{
+		Time:    now,
+		Year:    2015,
+		Day:     1,
+		Part:    1,
+		Answer:  answer,
+		Outcome: verdict.Outcome,
+		Hint:    verdict.Hint,
+		Wait:    verdict.Wait,
+	})
+}
+
+func TestHistoryRejectsWithoutRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HistoryFile)
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, requests := newMock(t)
+	now := time.Date(2015, time.December, 1, 12, 0, 0, 0, time.UTC)
+
+	if verdict, err := attempt(history, client, "41", now); err != nil || verdict.Hint != TooLow {
+		t.Fatalf("first attempt: %s, %v, want too low", verdict, err)
+	}
+
+	// read back from the file, as the next run of the command would
+	history, err = LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Attempts) != 1 || history.Attempts[0].Answer != "41" || history.Attempts[0].Hint != TooLow {
+		t.Fatalf("history %+v, want the one attempt at 41", history.Attempts)
+	}
+
+	later := now.Add(time.Hour)
+	tests := []struct {
+		answer string
+		want   string
+	}{
+		{"41", "already tried"},
+		{"40", "41 was too low"},
+	}
+	for _, tt := range tests {
+		_, err := attempt(history, client, tt.answer, later)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("attempt %s: %v, want an error with %q", tt.answer, err, tt.want)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("the server got %d requests, want 1 with the rest turned down from the history", got)
+	}
+}
+
+func TestHistoryCheck(t *testing.T) {
+	at := time.Date(2015, time.December, 1, 12, 0, 0, 0, time.UTC)
+	history := &History{Attempts: []Attempt{
+		{Time: at, Year: 2015, Day: 1, Part: 1, Answer: "10", Outcome: Wrong, Hint: TooLow, Wait: time.Minute},
+		{Time: at, Year: 2015, Day: 1, Part: 1, Answer: "50", Outcome: Wrong, Hint: TooHigh},
+		{Time: at, Year: 2015, Day: 1, Part: 1, Answer: "abc", Outcome: Wrong},
+		{Time: at, Year: 2015, Day: 1, Part: 2, Answer: "7", Outcome: Correct},
+	}}
+
+	tests := []struct {
+		part   int
+		answer string
+		now    time.Time
+		// want is part of the error, none when empty
+		want string
+	}{
+		{1, "30", at.Add(time.Hour), ""},
+		{1, "abd", at.Add(time.Hour), ""},
+		{1, "abc", at.Add(time.Hour), "already tried"},
+		{1, "10", at.Add(time.Hour), "already tried"},
+		{1, "9", at.Add(time.Hour), "10 was too low"},
+		{1, "50", at.Add(time.Hour), "already tried"},
+		{1, "60", at.Add(time.Hour), "50 was too high"},
+		{1, "30", at.Add(30 * time.Second), "30s left"},
+		{2, "8", at.Add(time.Hour), "already solved with 7"},
+	}
+	for _, tt := range tests {
+		err := history.Check(2015, 1, tt.part, tt.answer, tt.now)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("Check(part %d, %s): %v, want no error", tt.part, tt.answer, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("Check(part %d, %s): %v, want an error with %q", tt.part, tt.answer, err, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		page string
+		want Verdict
+	}{
+		{"<article><p>That's the right answer!</p></article>", Verdict{Outcome: Correct}},
+		{"<article><p>That's not the right answer; your answer is too high.  Please wait one minute before trying again.</p></article>", Verdict{Outcome: Wrong, Hint: TooHigh, Wait: time.Minute}},
+		{"<article><p>That's not the right answer.  Please wait 5 minutes before trying again.</p></article>", Verdict{Outcome: Wrong, Wait: 5 * time.Minute}},
+		{"<article><p>You gave an answer too recently.  You have 2m 5s left to wait.</p></article>", Verdict{Outcome: Wait, Wait: 2*time.Minute + 5*time.Second}},
+		{"<article><p>You don't seem to be solving the right level.</p></article>", Verdict{Outcome: Solved}},
+		{"<html>Something else</html>", Verdict{Outcome: Unknown}},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.page)
+		if got.Outcome != tt.want.Outcome || got.Hint != tt.want.Hint || got.Wait != tt.want.Wait {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.page, got, tt.want)
+		}
+	}
+}